@@ -1,9 +1,15 @@
 package gone
 
 import (
+	"context"
+	"fmt"
 	"os"
 	"os/signal"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"syscall"
+	"time"
 )
 
 // Application represents the core container and orchestrator for the Gone framework.
@@ -30,15 +36,54 @@ import (
 type Application struct {
 	Flag
 
-	loader  *core    `gone:"*"`
-	daemons []Daemon `gone:"*"`
+	loader *core `gone:"*"`
+
+	shutdownTimeout string `gone:"config,gone.shutdown.timeout=0s"`
+	profileStartup  string `gone:"config,gone.startup.profile=false"`
+	startedDaemons  []daemonRunner
 
 	beforeStartHooks []Process
 	afterStartHooks  []Process
 	beforeStopHooks  []Process
 	afterStopHooks   []Process
+	registeredHooks  []func()
+
+	signal          chan os.Signal
+	stopSignals     []os.Signal
+	reloadSignals   []os.Signal
+	watchingSignals atomic.Bool
+	exit            func(code int)
 
-	signal chan os.Signal
+	stateMutex sync.Mutex
+	state      AppState
+	started    chan struct{}
+}
+
+// AppState is the state of an Application in its lifecycle, see Application.State.
+type AppState int
+
+const (
+	StateCreated   AppState = iota // Goners can be loaded, and nothing is installed yet
+	StateInstalled                 // Goners are installed, and daemons are not started yet
+	StateStarted                   // daemons are started
+	StateStopping                  // the Application is being stopped
+	StateStopped                   // the Application is stopped, starting it again installs it again
+)
+
+func (s AppState) String() string {
+	switch s {
+	case StateCreated:
+		return "created"
+	case StateInstalled:
+		return "installed"
+	case StateStarted:
+		return "started"
+	case StateStopping:
+		return "stopping"
+	case StateStopped:
+		return "stopped"
+	}
+	return fmt.Sprintf("AppState(%d)", int(s))
 }
 
 // NewApp creates and initializes a new Application instance.
@@ -73,14 +118,18 @@ func Prepare(loads ...LoadFunc) *Application {
 
 func (s *Application) init() *Application {
 	s.signal = make(chan os.Signal, 1)
+	s.stopSignals = defaultStopSignals
+	s.reloadSignals = defaultReloadSignals
+	s.exit = os.Exit
+	s.started = make(chan struct{})
 	s.loader = newCore()
 
 	s.
-		Load(s, IsDefault()).
-		Load(&BeforeStartProvider{}).
-		Load(&AfterStartProvider{}).
-		Load(&BeforeStopProvider{}).
-		Load(&AfterStopProvider{})
+		Load(s, IsDefault(), builtin()).
+		Load(&BeforeStartProvider{}, builtin()).
+		Load(&AfterStartProvider{}, builtin()).
+		Load(&BeforeStopProvider{}, builtin()).
+		Load(&AfterStopProvider{}, builtin())
 	return s
 }
 
@@ -116,6 +165,24 @@ func (s *Application) Load(goner Goner, options ...Option) *Application {
 	return s
 }
 
+// LoadConstructor registers a constructor function into the Application's loader with optional configuration options.
+// Think of it as "hiring through an agency" - instead of bringing a ready employee, you hand over a recipe
+// describing how to produce one, and the Application calls it at the right moment, once everything the
+// recipe needs is ready. It wraps the Core.LoadConstructor() method and panics if loading fails.
+//
+// Parameters:
+//   - fn: A function like func(deps...) (*T, error), whose parameters are injected
+//   - options: Optional configuration options, the same as Load
+//
+// Returns the Application instance for method chaining
+func (s *Application) LoadConstructor(fn any, options ...Option) *Application {
+	err := s.loader.LoadConstructor(fn, options...)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
 // Loads executes multiple LoadFuncs in sequence to load goner for Application
 // Think of it as "batch hiring" where you bring multiple new employees into your
 // company at once, like during a "recruitment drive" or "team expansion".
@@ -153,10 +220,31 @@ func (s *Application) Loads(loads ...LoadFunc) *Application {
 //
 // Returns the Application instance for method chaining.
 func (s *Application) BeforeStart(fn Process) *Application {
-	s.beforeStart(fn)
+	return s.register(fn, s.beforeStart)
+}
+
+// register registers a hook by a public method, which is registered again when the Application is started again.
+func (s *Application) register(fn Process, hook func(fn Process)) *Application {
+	s.registeredHooks = append(s.registeredHooks, func() {
+		hook(fn)
+	})
+	hook(fn)
 	return s
 }
 
+// reset clears the hooks and the installation of Goners after the Application is stopped, so that it can be
+// started again.
+func (s *Application) reset() {
+	s.beforeStartHooks, s.afterStartHooks, s.beforeStopHooks, s.afterStopHooks = nil, nil, nil, nil
+	for _, register := range s.registeredHooks {
+		register()
+	}
+	s.loader.reset()
+	s.stateMutex.Lock()
+	s.started = make(chan struct{})
+	s.stateMutex.Unlock()
+}
+
 func (s *Application) beforeStart(fn Process) {
 	s.beforeStartHooks = append([]Process{fn}, s.beforeStartHooks...)
 }
@@ -174,8 +262,7 @@ func (s *Application) beforeStart(fn Process) {
 //
 // Returns the Application instance for method chaining.
 func (s *Application) AfterStart(fn Process) *Application {
-	s.afterStart(fn)
-	return s
+	return s.register(fn, s.afterStart)
 }
 
 func (s *Application) afterStart(fn Process) {
@@ -195,8 +282,7 @@ func (s *Application) afterStart(fn Process) {
 //
 // Returns the Application instance for method chaining.
 func (s *Application) BeforeStop(fn Process) *Application {
-	s.beforeStop(fn)
-	return s
+	return s.register(fn, s.beforeStop)
 }
 
 func (s *Application) beforeStop(fn Process) {
@@ -216,8 +302,7 @@ func (s *Application) beforeStop(fn Process) {
 //
 // Returns the Application instance for method chaining.
 func (s *Application) AfterStop(fn Process) *Application {
-	s.afterStop(fn)
-	return s
+	return s.register(fn, s.afterStop)
 }
 
 func (s *Application) afterStop(fn Process) {
@@ -233,11 +318,14 @@ func (s *Application) afterStop(fn Process) {
 // - SIGINT: Usually triggered by Ctrl+C ("manual closing")
 // - SIGTERM: System shutdown or process termination ("scheduled closing")
 // - SIGQUIT: Quit signal ("emergency closing")
+// - SIGHUP: Reload signal ("shift change"), which calls Reload and keeps waiting
+//
+// The signals can be changed by StopSignals and ReloadSignals. After WaitEnd returns, receiving a stop signal
+// again while the application is stopping exits the process at once with ExitForced.
 //
 // Returns the Application instance for method chaining.
 func (s *Application) WaitEnd() *Application {
-	signal.Notify(s.signal, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
-	<-s.signal
+	s.waitStopSignal()
 	return s
 }
 
@@ -245,6 +333,7 @@ func (s *Application) WaitEnd() *Application {
 // Think of it as the "official closing procedure" where you send the "closing signal"
 // to initiate graceful shutdown. This method triggers application termination by
 // sending a SIGINT signal.
+// To stop an Application started by Start, use Stop instead.
 //
 // Returns the Application instance for method chaining.
 func (s *Application) End() *Application {
@@ -252,51 +341,78 @@ func (s *Application) End() *Application {
 	return s
 }
 
-func (s *Application) start() {
+// start runs BeforeStart hooks, starts daemons and runs AfterStart hooks. It stops at the first failure,
+// and the daemons started before the failure are recorded, so that only they are stopped.
+func (s *Application) start(ctx context.Context) error {
 	for _, fn := range s.beforeStartHooks {
-		fn()
+		if err := runHook(fn, "BeforeStart"); err != nil {
+			return err
+		}
 	}
 
-	for _, daemon := range s.daemons {
-		err := daemon.Start()
+	for _, daemon := range s.daemonRunners() {
+		begin := time.Now()
+		err := SafeExecute(func() error {
+			return daemon.start(ctx)
+		})
+		s.loader.profiler.recordDaemon(daemon.name, time.Since(begin))
 		if err != nil {
-			panic(err)
+			return ToErrorWithMsg(err, "failed to start daemon "+daemon.name)
 		}
+		s.startedDaemons = append(s.startedDaemons, daemon)
 	}
 
 	for _, fn := range s.afterStartHooks {
-		fn()
+		if err := runHook(fn, "AfterStart"); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
-func (s *Application) stop() {
+// stop runs BeforeStop hooks, stops daemons, runs AfterStop hooks and destroys Goners. It continues when a hook
+// or a daemon fails, and returns all the failures as a MultiError.
+func (s *Application) stop(ctx context.Context) error {
+	var errs []error
 	for _, fn := range s.beforeStopHooks {
-		fn()
+		errs = append(errs, runHook(fn, "BeforeStop"))
 	}
 
-	for i := len(s.daemons) - 1; i >= 0; i-- {
-		err := s.daemons[i].Stop()
-		if err != nil {
-			panic(err)
-		}
-	}
+	ctx, cancel := s.shutdownContext(ctx)
+	defer cancel()
+	errs = append(errs, stopDaemons(ctx, s.startedDaemons)...)
+	s.startedDaemons = nil
 
 	for _, fn := range s.afterStopHooks {
-		fn()
+		errs = append(errs, runHook(fn, "AfterStop"))
 	}
+
+	errs = append(errs, s.loader.destroy())
+
+	err := NewMultiError(StopError, "failed to stop the application", errs...)
+	if err != nil {
+		s.loader.logger.Errorf("%s", err)
+		return err
+	}
+	return nil
 }
 
-func (s *Application) install() {
-	err := s.loader.Install()
+// runHook runs a hook, and returns the panic of the hook as an error.
+func runHook(fn Process, stage string) error {
+	err := SafeExecute(func() error {
+		fn()
+		return nil
+	})
 	if err != nil {
-		panic(err)
+		return ToErrorWithMsg(err, stage+" hook failed")
 	}
+	return nil
 }
 
 func (s *Application) collectHooks() {
 	coffins := s.loader.iKeeper.getAllCoffins()
 	for _, co := range coffins {
-		if co.goner != nil {
+		if co.goner != nil && !co.prototype && !co.requestScoped {
 			if start, ok := co.goner.(BeforeStarter); ok {
 				s.beforeStart(func() {
 					start.BeforeStart()
@@ -326,7 +442,8 @@ func (s *Application) collectHooks() {
 // Think of it as "opening your business for a specific task" - you unlock the doors,
 // turn on all systems, perform the specific work, then properly close everything down.
 // The function can have dependencies that will be automatically injected.
-// Panics if dependency injection or execution fails.
+// Panics if dependency injection or execution fails; an error returned by a function is logged, and the functions
+// after it are still executed. Use RunE to get the errors instead.
 //
 // The Complete Business Day Process:
 // 1. "System setup" - Install and initialize all components
@@ -338,9 +455,32 @@ func (s *Application) collectHooks() {
 // Parameters:
 //   - funcList: The function to execute with injected dependencies - the "main business tasks"
 func (s *Application) Run(funcList ...any) {
-	s.install()
-	s.collectHooks()
-	s.start()
+	if err := s.run(funcList, false); err != nil {
+		panic(err)
+	}
+}
+
+// RunE is like Run, but returns errors instead of panicking, so that it can be embedded in command line programs
+// and tests. The error can be mapped to a process exit code by ExitCode.
+//
+// It returns:
+//   - the error of installing the Application, when nothing has been started
+//   - the error of starting, after the daemons already started have been stopped
+//   - the error returned by a run function, whose last result is an error, after the Application has been stopped;
+//     the functions after it are not executed
+//   - the failures of stopping; stopping continues when a daemon or a hook fails, so every daemon is stopped and
+//     every hook is run, and all the failures are returned as a MultiError
+func (s *Application) RunE(funcList ...any) error {
+	return s.run(funcList, true)
+}
+
+// run executes funcList between starting and stopping the Application. An error returned by a function is returned
+// when returnErrors is true, otherwise it is logged, like Run does.
+func (s *Application) run(funcList []any, returnErrors bool) error {
+	ctx := context.Background()
+	if err := s.Start(ctx); err != nil {
+		return err
+	}
 
 	var options []RunOption
 	for _, fn := range funcList {
@@ -351,16 +491,129 @@ func (s *Application) Run(funcList ...any) {
 
 		f, err := s.loader.InjectWrapFunc(fn, nil, nil)
 		if err != nil {
-			panic(err)
+			return s.abort(ctx, err)
+		}
+		if err = returnedError(fn, f()); err != nil {
+			if !returnErrors {
+				s.loader.logger.Errorf("run function returned an error: %s", err)
+				continue
+			}
+			return s.abort(ctx, err)
 		}
-		_ = f()
 	}
 
 	for _, o := range options {
 		o.Apply(s)
 	}
 
-	s.stop()
+	return s.Stop(ctx)
+}
+
+// abort stops the Application after err occurred, and returns err together with the failures of stopping.
+// Unlike Stop, it also stops an Application which fails to start, so that the daemons already started are stopped.
+func (s *Application) abort(ctx context.Context, err error) error {
+	s.setState(StateStopping)
+	stopErr := s.shutdown(ctx)
+	if stopErr == nil {
+		return err
+	}
+	e := ToError(err)
+	return NewMultiError(e.Code(), e.Msg(), err, stopErr)
+}
+
+// Start installs the Application if it is not installed yet, runs BeforeStart hooks, starts daemons with ctx,
+// and runs AfterStart hooks, without waiting for a termination signal. It is used to embed the Application into
+// other programs, like httptest servers and cobra commands, which call Stop when they are finished:
+//
+//	app := gone.NewApp(loads...)
+//	if err := app.Start(ctx); err != nil {
+//	    return err
+//	}
+//	defer app.Stop(ctx)
+//
+// When starting fails, the daemons already started are stopped, and the error is returned.
+// A stopped Application is started again like it is created: the destroyed Goners are installed again, and the hooks
+// registered by them are registered again.
+// Start cannot be called when the Application is started or stopping, and it is not safe for concurrent use.
+func (s *Application) Start(ctx context.Context) error {
+	switch state := s.State(); state {
+	case StateStopped:
+		s.reset()
+		fallthrough
+	case StateCreated:
+		if err := s.loader.Install(); err != nil {
+			return newPhaseError(err, ExitInstallFailure)
+		}
+		s.setState(StateInstalled)
+	case StateInstalled:
+	default:
+		return NewInnerErrorWithParams(NotSupport, "cannot start the application which is %s", state)
+	}
+
+	s.collectHooks()
+	if err := s.start(ctx); err != nil {
+		return s.abort(ctx, newPhaseError(err, ExitStartFailure))
+	}
+	s.stateMutex.Lock()
+	s.state = StateStarted
+	close(s.started)
+	s.stateMutex.Unlock()
+	if profile, _ := strconv.ParseBool(s.profileStartup); profile {
+		s.loader.logger.Infof("%s", s.StartupProfile())
+	}
+	return nil
+}
+
+// Stop runs BeforeStop hooks, stops the started daemons, runs AfterStop hooks and destroys Goners.
+// The ctx passed to ContextDaemon.Stop is done when ctx is done, or the shutdown timeout expires.
+// Stopping continues when a daemon or a hook fails, and all the failures are returned as a MultiError.
+// Stop does nothing unless the Application is started, so Goners which are never started are not destroyed.
+func (s *Application) Stop(ctx context.Context) error {
+	s.stateMutex.Lock()
+	if s.state != StateStarted {
+		s.stateMutex.Unlock()
+		return nil
+	}
+	s.state = StateStopping
+	s.stateMutex.Unlock()
+	return s.shutdown(ctx)
+}
+
+// shutdown stops the Application which is stopping, see Stop.
+func (s *Application) shutdown(ctx context.Context) error {
+	if s.watchingSignals.Load() {
+		done := make(chan struct{})
+		defer func() {
+			close(done)
+			signal.Stop(s.signal)
+			s.watchingSignals.Store(false)
+		}()
+		go s.forceExitOnSignal(done)
+	}
+
+	err := s.stop(ctx)
+	s.setState(StateStopped)
+	return newPhaseError(err, ExitStopFailure)
+}
+
+// State returns the current state of the Application.
+func (s *Application) State() AppState {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	return s.state
+}
+
+// Started returns a channel, which is closed when the Application has been started successfully.
+func (s *Application) Started() <-chan struct{} {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	return s.started
+}
+
+func (s *Application) setState(state AppState) {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	s.state = state
 }
 
 // Serve initializes the application, starts all daemons, and waits for termination signal.
@@ -377,6 +630,12 @@ func (s *Application) Serve(funcList ...any) {
 	s.Run(funcList...)
 }
 
+// ServeE is like Serve, but returns errors instead of panicking, see RunE.
+func (s *Application) ServeE(funcList ...any) error {
+	funcList = append(funcList, OpWaitEnd())
+	return s.RunE(funcList...)
+}
+
 type RunOption interface {
 	Apply(*Application)
 }
@@ -416,7 +675,7 @@ func (*testFlag) forTest() {}
 // - Test mode indication via testFlag
 //
 func (s *Application) Test(fn any) {
-	s.Load(&testFlag{})
+	s.Load(&testFlag{}, builtin())
 	s.Run(fn)
 }
 
@@ -1,6 +1,7 @@
 package gone_test
 
 import (
+	"context"
 	"errors"
 	"go.uber.org/mock/gomock"
 	"reflect"
@@ -617,3 +618,167 @@ func TestApplication_collectHooks(t *testing.T) {
 		t.Errorf("afterStopOrder = %d, want %d", afterStopOrder, 4)
 	}
 }
+
+func TestApplication_RunE_StopErrors(t *testing.T) {
+	first := &MockDaemon{stopError: errors.New("first stop error")}
+	second := &MockDaemon{stopError: errors.New("second stop error")}
+	afterStopCalled := false
+
+	err := gone.NewApp().
+		Load(first, gone.Name("first")).
+		Load(second, gone.Name("second")).
+		BeforeStop(func() {
+			panic("before stop panic")
+		}).
+		AfterStop(func() {
+			afterStopCalled = true
+		}).
+		RunE()
+
+	var m gone.MultiError
+	if !errors.As(err, &m) || m.Code() != gone.StopError {
+		t.Fatalf("expected MultiError, got %v", err)
+	}
+	if len(m.Errors()) != 3 {
+		t.Errorf("expected 3 errors, got %v", m.Errors())
+	}
+	for _, msg := range []string{"before stop panic", "first stop error", "second stop error"} {
+		if !strings.Contains(err.Error(), msg) {
+			t.Errorf("error should contain %q", msg)
+		}
+	}
+	if !first.stopCalled || !second.stopCalled || !afterStopCalled {
+		t.Error("all daemons and hooks should run")
+	}
+}
+
+func TestApplication_StartStop(t *testing.T) {
+	daemon := &MockDaemon{}
+	app := gone.NewApp().Load(daemon)
+	if app.State() != gone.StateCreated {
+		t.Errorf("expected created, got %s", app.State())
+	}
+
+	select {
+	case <-app.Started():
+		t.Fatal("Started() should not be closed before Start")
+	default:
+	}
+
+	if err := app.Stop(context.Background()); err != nil || app.State() != gone.StateCreated || daemon.stopCalled {
+		t.Errorf("stopping an application which is not started should do nothing, got %v, %s", err, app.State())
+	}
+
+	if err := app.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if app.State() != gone.StateStarted || !daemon.startCalled {
+		t.Errorf("expected started, got %s", app.State())
+	}
+	select {
+	case <-app.Started():
+	default:
+		t.Error("Started() should be closed after Start")
+	}
+
+	if err := app.Start(context.Background()); err == nil {
+		t.Error("starting a started application should fail")
+	}
+
+	if err := app.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if app.State() != gone.StateStopped || !daemon.stopCalled {
+		t.Errorf("expected stopped, got %s", app.State())
+	}
+	if err := app.Stop(context.Background()); err != nil {
+		t.Errorf("stopping a stopped application should do nothing, got %v", err)
+	}
+}
+
+type cycleGoner struct {
+	gone.Flag
+	beforeStart gone.BeforeStart `gone:"*"`
+	inits       int
+	closes      int
+	hooks       int
+	starts      int
+}
+
+func (g *cycleGoner) Init() {
+	g.inits++
+	g.beforeStart(func() {
+		g.hooks++
+	})
+}
+
+func (g *cycleGoner) BeforeStart() {
+	g.starts++
+}
+
+func (g *cycleGoner) Close() error {
+	g.closes++
+	return nil
+}
+
+func TestApplication_StartAfterStop(t *testing.T) {
+	g := &cycleGoner{}
+	beforeStarts := 0
+	app := gone.NewApp().Load(g)
+	app.BeforeStart(func() {
+		beforeStarts++
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := app.Start(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if app.State() != gone.StateStarted {
+			t.Errorf("expected started, got %s", app.State())
+		}
+		select {
+		case <-app.Started():
+		default:
+			t.Error("Started() should be closed after Start")
+		}
+		if err := app.Stop(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if g.inits != 2 || g.closes != 2 {
+		t.Errorf("expected Init and Close to run twice, got %d and %d", g.inits, g.closes)
+	}
+	if beforeStarts != 2 || g.hooks != 2 || g.starts != 2 {
+		t.Errorf("expected every BeforeStart hook to run twice, got %d, %d and %d", beforeStarts, g.hooks, g.starts)
+	}
+}
+
+type stateDaemon struct {
+	gone.Flag
+	app    *gone.Application `gone:"*"`
+	states []gone.AppState
+}
+
+func (d *stateDaemon) Start(ctx context.Context) error {
+	d.states = append(d.states, d.app.State())
+	return nil
+}
+
+func (d *stateDaemon) Stop(ctx context.Context) error {
+	d.states = append(d.states, d.app.State())
+	return nil
+}
+
+func TestApplication_State(t *testing.T) {
+	d := &stateDaemon{}
+	gone.NewApp().Load(d).Run()
+
+	want := []gone.AppState{gone.StateInstalled, gone.StateStopping}
+	if !reflect.DeepEqual(d.states, want) {
+		t.Errorf("got %v, want %v", d.states, want)
+	}
+	if gone.AppState(100).String() != "AppState(100)" {
+		t.Error("unexpected string of unknown state")
+	}
+}
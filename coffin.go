@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"sync"
 )
 
 // coffin represents a component container in the gone framework
@@ -11,9 +12,13 @@ type coffin struct {
 	name  string
 	goner any
 
-	order        int
-	onlyForName  bool
-	forceReplace bool
+	order         int
+	onlyForName   bool
+	forceReplace  bool
+	prototype     bool
+	requestScoped bool
+	fallback      bool
+	builtin       bool
 
 	defaultTypeMap      map[reflect.Type]bool
 	lazyFill            bool
@@ -21,8 +26,25 @@ type coffin struct {
 	isFill              bool
 	isInit              bool
 	provider            *wrapProvider
+	constructor         *constructor
+	decorator           *decorator
 	namedProvider       NamedProvider
 	structFieldInjector StructFieldInjector
+	conditions          []condition
+
+	// deferredFields are fields skipped when co is filled, deferredInjections are fields of other goners
+	// injected after co is initialized, see AllowInterfaceCycles
+	deferredFields     map[string]bool
+	deferredInjections []deferredInjection
+
+	// provided are values supplied by co, which must be destroyed with it, see Destroyer
+	provided []any
+
+	initPolicy initPolicy
+
+	// injections are the fields of co injected so far, see Inspector
+	injectionsMutex sync.Mutex
+	injections      []injection
 }
 
 func newCoffin(goner any) *coffin {
@@ -34,6 +56,13 @@ func newCoffin(goner any) *coffin {
 	if namedGoner, ok := goner.(NamedGoner); ok {
 		co.name = namedGoner.GonerName()
 	}
+	if c, ok := goner.(*constructor); ok {
+		co.constructor = c
+	}
+	if d, ok := goner.(*decorator); ok {
+		co.needInitBeforeUse = true
+		co.decorator = d
+	}
 
 	if namedProvider, ok := goner.(NamedProvider); ok {
 		co.needInitBeforeUse = true
@@ -57,6 +86,12 @@ func (c *coffin) Name() string {
 	if c.name != "" {
 		return fmt.Sprintf("Goner(name=%s)", c.name)
 	}
+	if c.constructor != nil {
+		return fmt.Sprintf("Constructor(%s)", c.constructor.funcName())
+	}
+	if c.decorator != nil {
+		return fmt.Sprintf("Decorator(%s)", GetFuncName(c.decorator.fn))
+	}
 	return fmt.Sprintf("%T", c.goner)
 }
 
@@ -69,6 +104,10 @@ func (c *coffin) CoundProvide(t reflect.Type, byName bool) error {
 		return nil
 	}
 
+	if c.constructor != nil && c.constructor.typeCompatible(t) {
+		return nil
+	}
+
 	if c.namedProvider != nil && (byName || c.isDefault(t)) {
 		return nil
 	}
@@ -93,6 +132,10 @@ func (c *coffin) Provide(byName bool, tagConf string, t reflect.Type) (any, erro
 		return c.provider.Provide(tagConf)
 	}
 
+	if c.constructor != nil && c.constructor.typeCompatible(t) {
+		return c.constructor.provide()
+	}
+
 	if c.namedProvider != nil && (byName || c.isDefault(t)) {
 		return c.namedProvider.Provide(tagConf, t)
 	}
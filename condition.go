@@ -0,0 +1,215 @@
+package gone
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// condition decides whether a Goner loaded with a conditional option participates in the keeper.
+type condition struct {
+	desc  string
+	check func(ctx *conditionContext, co *coffin) (bool, error)
+}
+
+// conditionContext is used to evaluate conditions when the core is installed. Conditional Goners which have not
+// been evaluated yet are pending, and they are treated as not loaded by OnMissingType and OnPresentName.
+type conditionContext struct {
+	core      *core
+	pending   map[*coffin]bool
+	configure Configure
+	profiles  map[string]bool
+}
+
+func (c *conditionContext) isProfileActive(profile string) (bool, error) {
+	if c.profiles == nil {
+		configure, err := c.getConfigure()
+		if err != nil {
+			return false, err
+		}
+		var active string
+		if err = configure.Get(ProfilesActiveKey, &active, ""); err != nil {
+			return false, ToError(err)
+		}
+		c.profiles = make(map[string]bool)
+		for _, p := range parseProfiles(active) {
+			c.profiles[p] = true
+		}
+	}
+	return c.profiles[profile], nil
+}
+
+func (c *conditionContext) getConfigure() (Configure, error) {
+	if c.configure != nil {
+		return c.configure, nil
+	}
+	if co := c.core.iKeeper.getByName(ConfigureName); co == nil || c.pending[co] {
+		return nil, NewInnerErrorWithParams(GonerNameNotFound, "%q is required to check conditions on config", ConfigureName)
+	}
+	configure, err := c.core.installConfigure()
+	if err != nil {
+		return nil, err
+	}
+	c.configure = configure
+	return configure, nil
+}
+
+func (c *conditionContext) hasType(t reflect.Type, self *coffin) bool {
+	for _, co := range c.core.iKeeper.getByTypeAndPattern(t, "*") {
+		if co != self && !c.pending[co] {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *conditionContext) hasName(name string) bool {
+	co := c.core.iKeeper.getByName(name)
+	return co != nil && !c.pending[co]
+}
+
+// applyConditions evaluates the conditions of conditional Goners in load order, and removes the Goners whose
+// conditions are not met from the keeper.
+func (s *core) applyConditions() error {
+	var conditional []*coffin
+	for _, co := range s.iKeeper.getAllCoffins() {
+		if len(co.conditions) > 0 {
+			conditional = append(conditional, co)
+		}
+	}
+	if len(conditional) == 0 {
+		return nil
+	}
+
+	ctx := &conditionContext{core: s, pending: make(map[*coffin]bool)}
+	for _, co := range conditional {
+		ctx.pending[co] = true
+	}
+
+	for _, co := range conditional {
+		met := true
+		for _, c := range co.conditions {
+			ok, err := c.check(ctx, co)
+			if err != nil {
+				return ToErrorWithMsg(err, fmt.Sprintf("failed to check condition %s of %s", c.desc, co.Name()))
+			}
+			if !ok {
+				s.logger.Debugf("%s is not loaded, because condition %s is not met", co.Name(), c.desc)
+				met = false
+				break
+			}
+		}
+		delete(ctx.pending, co)
+		co.conditions = nil
+		if !met {
+			s.iKeeper.remove(co)
+		}
+	}
+	return nil
+}
+
+// OnConfig returns an Option that loads the Goner only when the configuration value of key equals value.
+// The value is read from the Configure named "configure" when the Application is installed.
+//
+// Example usage:
+//
+//	gone.Load(&RedisCache{}, gone.OnConfig("cache.enabled", "true"))
+func OnConfig(key, value string) Option {
+	return conditionOption(condition{
+		desc: fmt.Sprintf("OnConfig(%q, %q)", key, value),
+		check: func(ctx *conditionContext, co *coffin) (bool, error) {
+			configure, err := ctx.getConfigure()
+			if err != nil {
+				return false, err
+			}
+			var v string
+			if err = configure.Get(key, &v, ""); err != nil {
+				return false, ToError(err)
+			}
+			return v == value, nil
+		},
+	})
+}
+
+// OnMissingType returns an Option that loads the Goner only when no other Goner compatible with the type
+// pointed to by objPointer is loaded. It is usually used to load a default implementation, which gives way
+// to any other implementation loaded by the user. Among conditional Goners, the one loaded first wins.
+//
+// Example usage:
+//
+//	gone.Load(&MemoryCache{}, gone.OnMissingType(new(Cache)))
+func OnMissingType(objPointer any) Option {
+	t := reflect.TypeOf(objPointer)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return option{
+			apply: func(c *coffin) error {
+				return NewInnerErrorWithParams(LoadedError, "OnMissingType() requires a pointer, got %T", objPointer)
+			},
+		}
+	}
+	t = t.Elem()
+	return conditionOption(condition{
+		desc: fmt.Sprintf("OnMissingType(%s)", GetTypeName(t)),
+		check: func(ctx *conditionContext, co *coffin) (bool, error) {
+			return !ctx.hasType(t, co), nil
+		},
+	})
+}
+
+// OnPresentName returns an Option that loads the Goner only when a Goner with the given name is loaded.
+//
+// Example usage:
+//
+//	gone.Load(&RedisLocker{}, gone.OnPresentName("redis"))
+func OnPresentName(name string) Option {
+	return conditionOption(condition{
+		desc: fmt.Sprintf("OnPresentName(%q)", name),
+		check: func(ctx *conditionContext, co *coffin) (bool, error) {
+			return ctx.hasName(name), nil
+		},
+	})
+}
+
+// Profile returns an Option that loads the Goner only when one of the given profiles is active.
+// The active profiles are read from the config key "gone.profiles.active" (see ProfilesActiveKey) as a comma
+// separated list, and the profile "default" is active when no profile is configured. A profile prefixed with
+// "!" matches when the profile is not active.
+//
+// Example usage:
+//
+//	gone.Load(&MockPayment{}, gone.Profile("dev", "test"))
+//	gone.Load(&StripePayment{}, gone.Profile("!dev"))
+func Profile(profiles ...string) Option {
+	if len(profiles) == 0 {
+		return option{
+			apply: func(c *coffin) error {
+				return NewInnerError("Profile() requires at least one profile", LoadedError)
+			},
+		}
+	}
+	return conditionOption(condition{
+		desc: fmt.Sprintf("Profile(%s)", strings.Join(profiles, ", ")),
+		check: func(ctx *conditionContext, co *coffin) (bool, error) {
+			for _, p := range profiles {
+				negative := strings.HasPrefix(p, "!")
+				active, err := ctx.isProfileActive(strings.TrimPrefix(p, "!"))
+				if err != nil {
+					return false, err
+				}
+				if active != negative {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+	})
+}
+
+func conditionOption(c condition) Option {
+	return option{
+		apply: func(co *coffin) error {
+			co.conditions = append(co.conditions, c)
+			return nil
+		},
+	}
+}
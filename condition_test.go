@@ -0,0 +1,135 @@
+package gone
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type conditionCache interface {
+	Kind() string
+}
+
+type memoryConditionCache struct {
+	Flag
+}
+
+func (c *memoryConditionCache) Kind() string {
+	return "memory"
+}
+
+type redisConditionCache struct {
+	Flag
+}
+
+func (c *redisConditionCache) Kind() string {
+	return "redis"
+}
+
+func TestOnConfig(t *testing.T) {
+	t.Setenv("GONE_CACHE_ENABLED", "true")
+
+	NewApp().
+		Load(&redisConditionCache{}, OnConfig("cache.enabled", "true")).
+		Load(&memoryConditionCache{}, OnConfig("cache.enabled", "false")).
+		Run(func(keeper GonerKeeper) {
+			list := keeper.GetGonerByPattern(GetInterfaceType(new(conditionCache)), "*")
+			if len(list) != 1 || list[0].(conditionCache).Kind() != "redis" {
+				t.Errorf("only the goner whose config condition is met should be loaded, got %v", list)
+			}
+		})
+}
+
+func TestOnMissingType(t *testing.T) {
+	t.Run("other implementation is loaded", func(t *testing.T) {
+		NewApp().
+			Load(&memoryConditionCache{}, OnMissingType(new(conditionCache))).
+			Load(&redisConditionCache{}).
+			Run(func(cache conditionCache) {
+				if cache.Kind() != "redis" {
+					t.Errorf("unexpected cache %q", cache.Kind())
+				}
+			})
+	})
+
+	t.Run("first conditional goner wins", func(t *testing.T) {
+		NewApp().
+			Load(&memoryConditionCache{}, OnMissingType(new(conditionCache))).
+			Load(&redisConditionCache{}, OnMissingType(new(conditionCache))).
+			Run(func(keeper GonerKeeper) {
+				list := keeper.GetGonerByPattern(GetInterfaceType(new(conditionCache)), "*")
+				if len(list) != 1 || list[0].(conditionCache).Kind() != "memory" {
+					t.Errorf("only the first conditional goner should be loaded, got %v", list)
+				}
+			})
+	})
+
+	t.Run("not a pointer", func(t *testing.T) {
+		err := NewApp().loader.Load(&memoryConditionCache{}, OnMissingType(1))
+		if err == nil {
+			t.Error("OnMissingType should require a pointer")
+		}
+	})
+}
+
+func TestOnPresentName(t *testing.T) {
+	type locker struct {
+		Flag
+		cache conditionCache `gone:"redis"`
+	}
+
+	NewApp().
+		Load(&locker{}, OnPresentName("redis")).
+		Load(&memoryConditionCache{}, Name("memory")).
+		Run(func(keeper GonerKeeper) {
+			if keeper.GetGonerByType(reflect.TypeOf(&locker{})) != nil {
+				t.Error("goner should not be loaded when the name is absent")
+			}
+		})
+
+	NewApp().
+		Load(&locker{}, OnPresentName("redis")).
+		Load(&redisConditionCache{}, Name("redis")).
+		Run(func(l *locker) {
+			if l.cache.Kind() != "redis" {
+				t.Error("goner should be loaded when the name is present")
+			}
+		})
+}
+
+func TestProfile(t *testing.T) {
+	load := func(loader Loader) error {
+		if err := loader.Load(&memoryConditionCache{}, Profile("dev", "test")); err != nil {
+			return err
+		}
+		return loader.Load(&redisConditionCache{}, Profile("!dev", "!test"))
+	}
+	kind := func() (kind string) {
+		NewApp(load).Run(func(cache conditionCache) {
+			kind = cache.Kind()
+		})
+		return
+	}
+
+	if k := kind(); k != "redis" {
+		t.Errorf("redis cache should be loaded by default, got %q", k)
+	}
+	t.Setenv("GONE_GONE_PROFILES_ACTIVE", "local,test")
+	if k := kind(); k != "memory" {
+		t.Errorf("memory cache should be loaded for test profile, got %q", k)
+	}
+
+	if err := NewApp().loader.Load(&memoryConditionCache{}, Profile()); err == nil {
+		t.Error("Profile() should require at least one profile")
+	}
+}
+
+func TestConditions_error(t *testing.T) {
+	app := NewApp().Load(&redisConditionCache{}, OnConfig("cache.enabled", "true"))
+	app.loader.iKeeper.remove(app.loader.iKeeper.getByName(ConfigureName))
+
+	err := app.loader.Install()
+	if err == nil || !strings.Contains(err.Error(), "OnConfig") {
+		t.Errorf("unexpected error %v", err)
+	}
+}
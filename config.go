@@ -6,6 +6,7 @@ import (
 	"os"
 	"reflect"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -36,11 +37,17 @@ type confWatcherProvider struct {
 	Flag
 	logger    Logger    `gone:"*"`
 	configure Configure `gone:"configure"`
-	m         map[string][]ConfWatchFunc
+
+	mu sync.Mutex
+	m  map[string][]ConfWatchFunc
+
+	// values are the last values of the watched keys, which are compared with the values read on Reload
+	values map[string]string
 }
 
 func (p *confWatcherProvider) Init() {
 	p.m = make(map[string][]ConfWatchFunc)
+	p.values = make(map[string]string)
 }
 
 func (p *confWatcherProvider) Provide(string) (ConfWatcher, error) {
@@ -49,23 +56,71 @@ func (p *confWatcherProvider) Provide(string) (ConfWatcher, error) {
 		return nil, nil
 	} else {
 		return func(key string, callback ConfWatchFunc) {
+			p.mu.Lock()
+			_, watched := p.m[key]
 			p.m[key] = append(p.m[key], callback)
+			if !watched {
+				p.values[key] = p.read(key)
+			}
+			p.mu.Unlock()
+			if watched {
+				return
+			}
 			configure.Notify(key, func(oldVal, newVal any) {
-				funcs := p.m[key]
-				for _, f := range funcs {
-					err := SafeExecute(func() error {
-						f(oldVal, newVal)
-						return nil
-					})
-					if err != nil {
-						p.logger.Warnf("call %T err:%v", f, err)
-					}
-				}
+				p.mu.Lock()
+				p.values[key] = p.read(key)
+				p.mu.Unlock()
+				p.notify(key, oldVal, newVal)
 			})
 		}, nil
 	}
 }
 
+// Reload notifies the watchers of the keys whose values are changed since they are read last time, which are
+// changed by reloading the configure, see Application.Reload.
+func (p *confWatcherProvider) Reload() error {
+	type change struct {
+		key, oldVal, newVal string
+	}
+	var changes []change
+	p.mu.Lock()
+	for key, oldVal := range p.values {
+		if newVal := p.read(key); newVal != oldVal {
+			p.values[key] = newVal
+			changes = append(changes, change{key: key, oldVal: oldVal, newVal: newVal})
+		}
+	}
+	p.mu.Unlock()
+
+	for _, c := range changes {
+		p.notify(c.key, c.oldVal, c.newVal)
+	}
+	return nil
+}
+
+func (p *confWatcherProvider) read(key string) string {
+	var v string
+	if err := p.configure.Get(key, &v, ""); err != nil {
+		p.logger.Warnf("failed to read watched config %q: %v", key, err)
+	}
+	return v
+}
+
+func (p *confWatcherProvider) notify(key string, oldVal, newVal any) {
+	p.mu.Lock()
+	funcs := append([]ConfWatchFunc(nil), p.m[key]...)
+	p.mu.Unlock()
+	for _, f := range funcs {
+		err := SafeExecute(func() error {
+			f(oldVal, newVal)
+			return nil
+		})
+		if err != nil {
+			p.logger.Warnf("call %T err:%v", f, err)
+		}
+	}
+}
+
 // ConfigProvider implements a provider for injecting configuration values
 // It uses an underlying Configure implementation to retrieve values
 type ConfigProvider struct {
@@ -128,9 +183,18 @@ type EnvConfigure struct {
 
 const GONE = "GONE"
 
+// ProfilesActiveKey is the config key of the active profiles, which is a comma separated list like "dev,local".
+const ProfilesActiveKey = "gone.profiles.active"
+
+// DefaultProfile is the profile which is active when no profile is configured by ProfilesActiveKey.
+const DefaultProfile = "default"
+
 // Get retrieves a configuration value from environment variables with fallback to default value.
 // Supports type conversion for various Go types including string, int, float, bool, and structs.
 //
+// The value of an active profile overlays the common one: when the profiles "dev,local" are active,
+// the key "db.dsn" is looked up in GONE_LOCAL_DB_DSN, GONE_DEV_DB_DSN and then GONE_DB_DSN.
+//
 // Parameters:
 //   - key: Environment variable name to look up
 //   - v: Pointer to variable where the value will be stored
@@ -142,8 +206,7 @@ const GONE = "GONE"
 //   - Unsupported type is provided
 func (s *EnvConfigure) Get(key string, v any, defaultVal string) error {
 	// Get environment variable value, fallback to default if not set
-	key = convertUppercaseCamel(GONE + "_" + key)
-	env := os.Getenv(key)
+	env := s.lookup(key)
 	if env == "" {
 		env = defaultVal
 	}
@@ -166,6 +229,31 @@ func (s *EnvConfigure) Get(key string, v any, defaultVal string) error {
 	return SetValue(rv, v, env)
 }
 
+func (s *EnvConfigure) lookup(key string) string {
+	if active := os.Getenv(convertUppercaseCamel(GONE + "_" + ProfilesActiveKey)); active != "" && key != ProfilesActiveKey {
+		profiles := parseProfiles(active)
+		for i := len(profiles) - 1; i >= 0; i-- {
+			if env := os.Getenv(convertUppercaseCamel(GONE + "_" + profiles[i] + "." + key)); env != "" {
+				return env
+			}
+		}
+	}
+	return os.Getenv(convertUppercaseCamel(GONE + "_" + key))
+}
+
+// parseProfiles splits a comma separated profile list, the DefaultProfile is returned if the list is empty.
+func parseProfiles(value string) (profiles []string) {
+	for _, p := range strings.Split(value, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			profiles = append(profiles, p)
+		}
+	}
+	if len(profiles) == 0 {
+		profiles = []string{DefaultProfile}
+	}
+	return profiles
+}
+
 var UnsupportedError = NewInnerError("Unsupported type by EnvConfigure", ConfigError)
 
 // SetValue sets the value of a pointer to a Go type based on the provided value and environment variable.
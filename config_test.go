@@ -323,6 +323,46 @@ func TestEnvConfigure_Get(t *testing.T) {
 	}
 }
 
+func TestEnvConfigure_GetWithProfiles(t *testing.T) {
+	t.Setenv("GONE_DB_DSN", "common")
+	t.Setenv("GONE_DEV_DB_DSN", "dev")
+	t.Setenv("GONE_LOCAL_DB_DSN", "local")
+	t.Setenv("GONE_DEV_DB_USER", "dev-user")
+
+	s := &EnvConfigure{}
+	get := func(key string) string {
+		var v string
+		if err := s.Get(key, &v, ""); err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	if v := get("db.dsn"); v != "common" {
+		t.Errorf("without active profiles, got %q", v)
+	}
+
+	t.Setenv("GONE_GONE_PROFILES_ACTIVE", "dev, local")
+	if v := get("db.dsn"); v != "local" {
+		t.Errorf("the last active profile should win, got %q", v)
+	}
+	if v := get("db.user"); v != "dev-user" {
+		t.Errorf("value of an active profile should overlay the common one, got %q", v)
+	}
+	if v := get(ProfilesActiveKey); v != "dev, local" {
+		t.Errorf("unexpected active profiles %q", v)
+	}
+}
+
+func Test_parseProfiles(t *testing.T) {
+	if p := parseProfiles(""); len(p) != 1 || p[0] != DefaultProfile {
+		t.Errorf("default profile should be active, got %v", p)
+	}
+	if p := parseProfiles(" dev ,, test"); len(p) != 2 || p[0] != "dev" || p[1] != "test" {
+		t.Errorf("unexpected profiles %v", p)
+	}
+}
+
 func TestConfigProvider_Init(t *testing.T) {
 	provider := &ConfigProvider{}
 	// Init() should not panic
@@ -0,0 +1,72 @@
+package gone
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// constructor is the Goner registered by LoadConstructor. It calls the constructor function when it is initialized,
+// after all dependencies of the constructor parameters are initialized, and provides the constructed value.
+type constructor struct {
+	Flag
+	injector FuncInjector `gone:"*"`
+
+	fn    any
+	t     reflect.Type
+	value any
+	built bool
+}
+
+func newConstructor(fn any) (*constructor, error) {
+	if fn == nil {
+		return nil, NewInnerError("constructor cannot be nil - must provide a function", LoadedError)
+	}
+	ft := reflect.TypeOf(fn)
+	if ft.Kind() != reflect.Func {
+		return nil, NewInnerErrorWithParams(LoadedError, "constructor must be a function, got %T", fn)
+	}
+	if ft.IsVariadic() {
+		return nil, NewInnerErrorWithParams(LoadedError, "constructor %s cannot be variadic", GetFuncName(fn))
+	}
+	if !(ft.NumOut() == 1 || ft.NumOut() == 2 && ft.Out(1) == errType) {
+		return nil, NewInnerErrorWithParams(LoadedError,
+			"constructor %s must return a value, or a value and an error", GetFuncName(fn),
+		)
+	}
+	return &constructor{fn: fn, t: ft.Out(0)}, nil
+}
+
+func (c *constructor) funcName() string {
+	return GetFuncName(c.fn)
+}
+
+func (c *constructor) Init() error {
+	f, err := c.injector.InjectWrapFunc(c.fn, nil, nil)
+	if err != nil {
+		return ToErrorWithMsg(err, fmt.Sprintf("failed to inject parameters of constructor %s", c.funcName()))
+	}
+	results := f()
+	if len(results) == 2 && results[1] != nil {
+		return ToErrorWithMsg(results[1], fmt.Sprintf("constructor %s returned error", c.funcName()))
+	}
+	if results[0] == nil {
+		return NewInnerErrorWithParams(ProviderError, "constructor %s returned nil", c.funcName())
+	}
+	c.value = results[0]
+	c.built = true
+	return nil
+}
+
+func (c *constructor) typeCompatible(t reflect.Type) bool {
+	if c.t == t {
+		return true
+	}
+	return t.Kind() == reflect.Interface && c.t.Implements(t)
+}
+
+func (c *constructor) provide() (any, error) {
+	if !c.built {
+		return nil, NewInnerErrorWithParams(ProviderError, "constructor %s has not been called yet", c.funcName())
+	}
+	return c.value, nil
+}
@@ -0,0 +1,118 @@
+package gone
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_newConstructor(t *testing.T) {
+	type thirdParty struct{}
+
+	tests := []struct {
+		name    string
+		fn      any
+		wantErr bool
+	}{
+		{name: "nil", fn: nil, wantErr: true},
+		{name: "not function", fn: 1, wantErr: true},
+		{name: "variadic", fn: func(...int) *thirdParty { return nil }, wantErr: true},
+		{name: "no return", fn: func() {}, wantErr: true},
+		{name: "second return is not error", fn: func() (*thirdParty, int) { return nil, 0 }, wantErr: true},
+		{name: "value only", fn: func() *thirdParty { return nil }, wantErr: false},
+		{name: "value and error", fn: func(Logger) (*thirdParty, error) { return nil, nil }, wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newConstructor(tt.fn); (err != nil) != tt.wantErr {
+				t.Errorf("newConstructor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_constructor_provide(t *testing.T) {
+	type thirdParty struct{}
+
+	c, _ := newConstructor(func() (*thirdParty, error) {
+		return nil, errors.New("err")
+	})
+	if _, err := c.provide(); err == nil {
+		t.Errorf("provide() should be error before constructor is called")
+	}
+
+	co := newCoffin(c)
+	if !co.needInitBeforeUse || co.constructor != c {
+		t.Errorf("constructor coffin should be initialized before use")
+	}
+	if co.Name() != "Constructor(github.com/gone-io/gone/v2.Test_constructor_provide.func1)" {
+		t.Errorf("unexpected coffin name %q", co.Name())
+	}
+}
+
+func TestCore_LoadConstructor(t *testing.T) {
+	type thirdParty struct {
+		logger Logger
+	}
+
+	t.Run("suc", func(t *testing.T) {
+		NewApp().
+			LoadConstructor(func(logger Logger) *thirdParty {
+				return &thirdParty{logger: logger}
+			}).
+			Run(func(p *thirdParty) {
+				if p.logger == nil {
+					t.Errorf("constructor parameters should be injected")
+				}
+			})
+	})
+
+	t.Run("load by LoadFunc", func(t *testing.T) {
+		NewApp(func(loader Loader) error {
+			l, ok := loader.(ConstructorLoader)
+			if !ok {
+				t.Fatal("the loader of Application should be a ConstructorLoader")
+			}
+			return l.LoadConstructor(func(logger Logger) *thirdParty {
+				return &thirdParty{logger: logger}
+			})
+		}).Run(func(p *thirdParty) {
+			if p.logger == nil {
+				t.Errorf("constructor parameters should be injected")
+			}
+		})
+	})
+
+	t.Run("load error", func(t *testing.T) {
+		if err := newCore().LoadConstructor(1); err == nil {
+			t.Errorf("LoadConstructor() should be error")
+		}
+	})
+
+	t.Run("constructor error", func(t *testing.T) {
+		err := SafeExecute(func() error {
+			NewApp().
+				LoadConstructor(func() (*thirdParty, error) {
+					return nil, errors.New("err")
+				}).
+				Run()
+			return nil
+		})
+		if err == nil {
+			t.Errorf("constructor error should fail the installation")
+		}
+	})
+
+	t.Run("constructor returns nil", func(t *testing.T) {
+		err := SafeExecute(func() error {
+			NewApp().
+				LoadConstructor(func() *thirdParty {
+					return nil
+				}).
+				Run()
+			return nil
+		})
+		if err == nil {
+			t.Errorf("constructor returning nil should fail the installation")
+		}
+	})
+}
@@ -3,6 +3,7 @@ package gone
 import (
 	"fmt"
 	"reflect"
+	"time"
 )
 
 func newCore() *core {
@@ -10,26 +11,68 @@ func newCore() *core {
 	l := GetDefaultLogger()
 	a := newDependenceAnalyzer(k, l)
 	i := newInstaller(a, l)
+	i.keeper = k
+	i.profiler = newStartupProfiler()
 	c := &core{
 		iKeeper:             k,
 		iDependenceAnalyzer: a,
 		iInstaller:          i,
 		logger:              l,
 		loaderMap:           make(map[LoaderKey]struct{}),
+		profiler:            i.profiler,
 	}
 
-	_ = k.load(k)
-	_ = k.load(a)
-	_ = k.load(i)
-	_ = k.load(&ConfigProvider{})
-	_ = k.load(&confWatcherProvider{})
-	_ = k.load(&EnvConfigure{}, Name("configure"), IsDefault(new(Configure)))
-	_ = k.load(l.(Goner), IsDefault(new(Logger)))
-	_ = k.load(c, Name(DefaultProviderName))
+	_ = k.load(k, builtin())
+	_ = k.load(a, builtin())
+	_ = k.load(i, builtin())
+	_ = k.load(&ConfigProvider{}, builtin())
+	_ = k.load(&confWatcherProvider{}, builtin())
+	_ = k.load(&EnvConfigure{}, Name("configure"), IsDefault(new(Configure)), IsFallback(), builtin())
+	_ = k.load(l.(Goner), IsDefault(new(Logger)), IsFallback(), builtin())
+	_ = k.load(c, Name(DefaultProviderName), builtin())
+	_ = k.load(&inspector{keeper: k}, builtin())
 
 	return c
 }
 
+// newChild creates a core for a child container. The keeper of the child falls back to the keeper of s,
+// so goners loaded into the child can be injected with everything loaded into s, while s never sees
+// goners of the child.
+func (s *core) newChild() *core {
+	return s.newChildWithRequestInstances(s.requestInstances)
+}
+
+// newRequestChild creates a child core for a request scope, in which each request scoped goner has one instance.
+func (s *core) newRequestChild() *core {
+	c := s.newChildWithRequestInstances(&requestInstances{instances: make(map[*coffin]any)})
+	c.ownsRequestInstances = true
+	return c
+}
+
+func (s *core) newChildWithRequestInstances(requestInstances *requestInstances) *core {
+	k := newChildKeeper(s.iKeeper)
+	a := newDependenceAnalyzer(k, s.logger)
+	i := newInstaller(a, s.logger)
+	i.requestInstances = requestInstances
+	i.keeper = k
+	i.parent, _ = s.iInstaller.(*installer)
+	c := &core{
+		iKeeper:             k,
+		iDependenceAnalyzer: a,
+		iInstaller:          i,
+		logger:              s.logger,
+		parent:              s,
+		requestInstances:    requestInstances,
+		loaderMap:           make(map[LoaderKey]struct{}),
+	}
+
+	_ = k.load(k, builtin())
+	_ = k.load(a, builtin())
+	_ = k.load(i, builtin())
+	_ = k.load(c, Name(DefaultProviderName), builtin())
+	return c
+}
+
 type core struct {
 	Flag
 	iKeeper             iKeeper
@@ -37,7 +80,18 @@ type core struct {
 	iDependenceAnalyzer iDependenceAnalyzer
 	logger              Logger `gone:"*"`
 
-	loaderMap map[LoaderKey]struct{}
+	parent           *core
+	requestInstances *requestInstances
+	installed        bool
+	installOrder     []*coffin
+	loaderMap        map[LoaderKey]struct{}
+
+	// ownsRequestInstances is true for the core of a request scope, which destroys the instances of request scoped
+	// goners when it is destroyed; child scopes of the request scope share the instances without owning them
+	ownsRequestInstances bool
+
+	// profiler records the durations of the installation, it is nil for child cores
+	profiler *startupProfiler
 }
 
 // InjectFuncParameters injects parameters into a function by:
@@ -163,28 +217,40 @@ func (s *core) InjectStruct(goner any) error {
 func (s *core) GetGonerByName(name string) any {
 	co := s.iKeeper.getByName(name)
 	if co != nil {
+		if co.prototype || co.requestScoped {
+			// a prototype supplies a new copy, and a request scoped goner supplies the copy of the current request scope
+			if v, err := s.iInstaller.provide(co, true, "", reflect.TypeOf(co.goner)); err != nil {
+				panic(err)
+			} else {
+				return v
+			}
+		}
 		return co.goner
 	}
 	return nil
 }
 
 func (s *core) GetGonerByType(t reflect.Type) any {
+	if v, err := s.getGonerByType(t); err != nil {
+		panic(err)
+	} else {
+		return v
+	}
+}
+
+func (s *core) getGonerByType(t reflect.Type) (any, error) {
 	if co := s.iKeeper.selectOneCoffin(t, "*", func() {
 		s.logger.Warnf("found multiple value without a default when calling GetGonerByType(%s) - using first one. ", GetTypeName(t))
 	}); co != nil {
-		if v, err := co.Provide(false, "", t); err != nil {
-			panic(err)
-		} else {
-			return v
-		}
+		return s.iInstaller.provide(co, false, "", t)
 	}
-	return nil
+	return nil, nil
 }
 
 func (s *core) GetGonerByPattern(t reflect.Type, pattern string) (list []any) {
 	coffins := s.iKeeper.getByTypeAndPattern(t, pattern)
 	for _, co := range coffins {
-		if v, err := co.Provide(false, "", t); err != nil {
+		if v, err := s.iInstaller.provide(co, false, "", t); err != nil {
 			panic(err)
 		} else {
 			list = append(list, v)
@@ -193,14 +259,28 @@ func (s *core) GetGonerByPattern(t reflect.Type, pattern string) (list []any) {
 	return
 }
 
-func (s *core) ProvideNth(n int, t reflect.Type, funcName string) (reflect.Value, error) {
-	field := reflect.StructField{
+// newParameterField creates a struct field standing for the nth parameter of a function, which allows nil,
+// because a struct parameter can still be created and injected when no compatible value is found.
+func newParameterField(n int, t reflect.Type) reflect.StructField {
+	return reflect.StructField{
 		Name: fmt.Sprintf("The%dthParameter", n),
 		Type: t,
 		Tag:  `gone:"*" option:"allowNil"`,
 	}
+}
+
+func (s *core) ProvideNth(n int, t reflect.Type, funcName string) (reflect.Value, error) {
+	field := newParameterField(n, t)
 	v := reflect.New(t).Elem()
 
+	if target, ok := optionalTargetField(field); ok {
+		optional := reflect.New(t)
+		if _, err := injectOptional(s.iInstaller, target, optional.Elem(), funcName); err != nil {
+			return v, ToErrorWithMsg(err, fmt.Sprintf("can not provide nth parameter for %s", funcName))
+		}
+		return optional.Elem(), nil
+	}
+
 	if err := s.iInstaller.analyzerFieldDependencies(field, funcName, func(asSlice, byName bool, extend string, coffins ...*coffin) error {
 		return s.iInstaller.injectField(asSlice, byName, extend, coffins, field, v, funcName)
 	}); err != nil {
@@ -229,35 +309,136 @@ func (s *core) Check() ([]dependency, error) {
 		}
 	}
 	for _, co := range s.iKeeper.getAllCoffins() {
+		if co.prototype || co.requestScoped {
+			continue
+		}
 		orders = append(orders, dependency{co, fillAction})
 	}
 	return RemoveRepeat(orders), nil
 }
 
+// installConfigure installs the Goner named "configure" before other Goners, so that the configuration can be read
+// to decide how the other Goners are installed. The configure should only depend on Goners which need no initialization.
+func (s *core) installConfigure() (Configure, error) {
+	co := s.iKeeper.getByName(ConfigureName)
+	if co == nil {
+		return nil, NewInnerErrorWithParams(GonerNameNotFound, "%q is not loaded", ConfigureName)
+	}
+	if !co.isFill {
+		if err := s.iInstaller.safeFillOne(co); err != nil {
+			return nil, ToErrorWithMsg(err, fmt.Sprintf("failed to fill %q", ConfigureName))
+		}
+	}
+	if !co.isInit {
+		if err := s.iInstaller.safeInitOne(co); err != nil {
+			return nil, ToErrorWithMsg(err, fmt.Sprintf("failed to initialize %q", ConfigureName))
+		}
+		s.installOrder = append(s.installOrder, co)
+	}
+
+	configure, ok := co.goner.(Configure)
+	if !ok {
+		return nil, NewInnerErrorWithParams(GonerTypeNotMatch, "%q does not implement Configure", ConfigureName)
+	}
+	return configure, nil
+}
+
 func (s *core) Install() error {
+	s.profiler.reset()
+	if err := s.applyConditions(); err != nil {
+		return ToError(err)
+	}
+
 	orders, err := s.Check()
 	if err != nil {
 		return ToError(err)
 	}
 
-	for i, dep := range orders {
-		if dep.action == fillAction {
-			if err = s.iInstaller.safeFillOne(dep.coffin); err != nil {
-				s.logger.Debugf("failed to %s at order[%d]: %s", dep, i, err)
-				return ToError(err)
-			}
+	strict := s.isStrict()
+	if strict {
+		if err = s.iDependenceAnalyzer.checkStrict(); err != nil {
+			return ToError(err)
+		}
+	}
+
+	if workers := s.parallelWorkers(); workers > 1 {
+		err = s.installParallel(orders, workers)
+	} else {
+		err = s.installSerial(orders)
+	}
+	if err != nil {
+		return ToError(err)
+	}
+	if !strict {
+		if strict, err = s.isStrictByConfig(); err != nil {
+			return ToError(err)
 		}
-		if dep.action == initAction {
-			if err = s.iInstaller.safeInitOne(dep.coffin); err != nil {
-				s.logger.Debugf("failed to %s at order[%d]: %s", dep, i, err)
+		if strict {
+			if err = s.iDependenceAnalyzer.checkStrict(); err != nil {
 				return ToError(err)
 			}
 		}
 	}
+	if s.profiler != nil {
+		s.profiler.installed(orders, s.iDependenceAnalyzer.getInstallGraph())
+	}
+	s.installed = true
+	return nil
+}
+
+func (s *core) installSerial(orders []dependency) error {
+	last := make(map[*coffin]int, len(orders))
+	for i, dep := range orders {
+		last[dep.coffin] = i
+	}
+
+	for i, dep := range orders {
+		if isInstalled(dep) {
+			// goners of parent container, and the configure used by conditions, are already installed
+			continue
+		}
+		if err := s.installOne(i, dep); err != nil {
+			return err
+		}
+		if last[dep.coffin] == i {
+			s.installOrder = append(s.installOrder, dep.coffin)
+		}
+	}
+	return nil
+}
+
+func isInstalled(dep dependency) bool {
+	return dep.action == fillAction && dep.coffin.isFill || dep.action == initAction && dep.coffin.isInit
+}
+
+// installOne executes the action of dep, which is at order[i].
+func (s *core) installOne(i int, dep dependency) error {
+	if s.profiler != nil {
+		start := time.Now()
+		defer func() {
+			s.profiler.recordAction(dep, time.Since(start))
+		}()
+	}
+	if dep.action == fillAction {
+		if err := s.iInstaller.safeFillOne(dep.coffin); err != nil {
+			s.logger.Debugf("failed to %s at order[%d]: %s", dep, i, err)
+			return err
+		}
+	}
+	if dep.action == initAction {
+		if err := s.iInstaller.safeInitOne(dep.coffin); err != nil {
+			s.logger.Debugf("failed to %s at order[%d]: %s", dep, i, err)
+			return err
+		}
+		if err := s.injectDeferredFields(dep.coffin); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 var _ GonerKeeper = (*core)(nil)
 var _ Loader = (*core)(nil)
+var _ ConstructorLoader = (*core)(nil)
 var _ StructInjector = (*core)(nil)
 var _ FuncInjector = (*core)(nil)
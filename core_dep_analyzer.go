@@ -1,6 +1,7 @@
 package gone
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
 )
@@ -117,12 +118,27 @@ type dependenceAnalyzer struct {
 	Flag
 	iKeeper
 	logger Logger `gone:"*"`
+
+	// deps is the dependency graph found by the last checkCircularDepsAndGetBestInitOrder
+	deps map[dependency][]dependency
 }
 
 func (s *dependenceAnalyzer) collectDeps() (map[dependency][]dependency, error) {
 	depsMap := make(map[dependency][]dependency)
 	for _, co := range s.iKeeper.getAllCoffins() {
-		fillDependency, initDependency, err := s.getGonerDeps(co)
+		if co.requestScoped {
+			// request scoped goners may depend on goners which only exist in a request scope, like the
+			// context.Context of the request, so they are analyzed when they are injected in a request scope
+			continue
+		}
+		if co.prototype {
+			// prototypes are never installed themselves, only checked for depending on themselves
+			if _, err := s.getPrototypeDeps(co, nil); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		fillDependency, initDependency, err := s.getGonerDeps(co, nil)
 		if err != nil {
 			return nil, ToError(err)
 		}
@@ -141,18 +157,48 @@ func (s *dependenceAnalyzer) collectDeps() (map[dependency][]dependency, error)
 	return depsMap, nil
 }
 
-func (s *dependenceAnalyzer) getGonerDeps(co *coffin) (fillDependencies, initDependencies []dependency, err error) {
-	fillDependencies, err = s.getGonerFillDeps(co)
+// fieldVisitor is called with the goners which a field or a parameter is injected with, when collecting dependencies.
+type fieldVisitor func(field string, coffins []*coffin)
+
+// getGonerDeps collects the dependencies of filling and initializing co. visit, if it is not nil, is called with the
+// goners of every field of co, and of every parameter of its constructor or decorator, see Application.Graph.
+func (s *dependenceAnalyzer) getGonerDeps(co *coffin, visit func(action actionType, field string, coffins []*coffin)) (fillDependencies, initDependencies []dependency, err error) {
+	var visitFill, visitInit fieldVisitor
+	if visit != nil {
+		visitFill = func(field string, coffins []*coffin) { visit(fillAction, field, coffins) }
+		visitInit = func(field string, coffins []*coffin) { visit(initAction, field, coffins) }
+	}
+	fillDependencies, err = s.getFillDepsWithPrototypePath(co, nil, visitFill)
 	if !co.lazyFill {
 		initDependencies = append(initDependencies, dependency{
 			coffin: co,
 			action: fillAction,
 		})
 	}
+	if err == nil && co.constructor != nil {
+		// the constructor is called when co is initialized, so its parameters must be ready before that
+		var paramDependencies []dependency
+		paramDependencies, err = s.getFuncParamDeps(co.constructor.fn, 0, co.Name(), visitInit)
+		initDependencies = append(initDependencies, paramDependencies...)
+	}
+	if err == nil && co.decorator != nil {
+		// the first parameter of a decorator is the value to decorate, the others are injected when decorating
+		var paramDependencies []dependency
+		paramDependencies, err = s.getFuncParamDeps(co.decorator.fn, 1, co.Name(), visitInit)
+		initDependencies = append(initDependencies, paramDependencies...)
+	}
 	return
 }
 
 func (s *dependenceAnalyzer) getGonerFillDeps(co *coffin) (fillDependencies []dependency, err error) {
+	return s.getFillDepsWithPrototypePath(co, nil, nil)
+}
+
+// getFillDepsWithPrototypePath collects the fill dependencies of co. A prototype (or request scoped goner) is
+// filled and initialized at the injection point, so depending on a prototype means depending on everything the
+// prototype itself needs; prototypePath records the prototypes being expanded to detect prototypes which depend
+// on themselves.
+func (s *dependenceAnalyzer) getFillDepsWithPrototypePath(co *coffin, prototypePath []*coffin, visit fieldVisitor) (fillDependencies []dependency, err error) {
 	of := reflect.TypeOf(co.goner)
 	if of.Kind() != reflect.Ptr {
 		return nil, NewInnerError("goner must be a pointer", GonerTypeNotMatch)
@@ -161,41 +207,156 @@ func (s *dependenceAnalyzer) getGonerFillDeps(co *coffin) (fillDependencies []de
 	elem := of.Elem()
 	switch elem.Kind() {
 	case reflect.Struct:
-		for i := 0; i < elem.NumField(); i++ {
-			field := elem.Field(i)
+		return s.getStructFieldDeps(elem, co.Name(), prototypePath, visit)
+	default:
+		return nil, nil
+	}
+}
 
-			if isLazyField(&field) {
-				continue
-			}
+func (s *dependenceAnalyzer) getStructFieldDeps(elem reflect.Type, coName string, prototypePath []*coffin, visit fieldVisitor) (fillDependencies []dependency, err error) {
+	for i := 0; i < elem.NumField(); i++ {
+		field := elem.Field(i)
 
-			if err = s.analyzerFieldDependencies(
-				field,
-				co.Name(),
-				func(asSlice, byName bool, extend string, depCoffins ...*coffin) error {
-					for _, depCo := range depCoffins {
-						if depCo.needInitBeforeUse {
-							fillDependencies = append(fillDependencies, dependency{
-								coffin: depCo,
-								action: initAction,
-							})
-						}
-					}
-					return nil
-				},
-			); err != nil {
+		if isLazyField(&field) {
+			continue
+		}
+		if _, ok := lazyTargetField(field); ok {
+			// lazy handles are resolved when they are called
+			continue
+		}
+		if target, ok := optionalTargetField(field); ok {
+			field = target
+		}
+
+		if err = s.analyzerFieldDependencies(
+			field,
+			coName,
+			func(asSlice, byName bool, extend string, depCoffins ...*coffin) error {
+				if visit != nil {
+					visit(field.Name, depCoffins)
+				}
+				deps, err := s.getUseDeps(depCoffins, prototypePath)
+				fillDependencies = append(fillDependencies, deps...)
+				fillDependencies = append(fillDependencies, s.getDecoratorDeps(field.Type, asSlice)...)
+				return err
+			},
+		); err != nil {
+			return nil, err
+		}
+	}
+	return RemoveRepeat(fillDependencies), nil
+}
+
+// getUseDeps returns the actions which must be done before depCoffins can be injected.
+func (s *dependenceAnalyzer) getUseDeps(depCoffins []*coffin, prototypePath []*coffin) (deps []dependency, err error) {
+	for _, depCo := range depCoffins {
+		if depCo.prototype || depCo.requestScoped {
+			prototypeDeps, err := s.getPrototypeDeps(depCo, prototypePath)
+			if err != nil {
 				return nil, err
 			}
+			deps = append(deps, prototypeDeps...)
+		} else if depCo.needInitBeforeUse {
+			deps = append(deps, dependency{
+				coffin: depCo,
+				action: initAction,
+			})
+		}
+	}
+	return deps, nil
+}
+
+// getDecoratorDeps returns the decorators which must be initialized before a value of type t is injected,
+// t is a slice of the values when asSlice is true.
+func (s *dependenceAnalyzer) getDecoratorDeps(t reflect.Type, asSlice bool) (deps []dependency) {
+	if asSlice {
+		t = t.Elem()
+	}
+	for _, co := range s.iKeeper.getDecorators(t) {
+		deps = append(deps, dependency{coffin: co, action: initAction})
+	}
+	return deps
+}
+
+// getFuncParamDeps collects the dependencies of the parameters of fn from the index from, which are injected in the
+// same way as InjectFuncParameters does: by a compatible goner, or by a struct whose fields are injected.
+func (s *dependenceAnalyzer) getFuncParamDeps(fn any, from int, coName string, visit fieldVisitor) (deps []dependency, err error) {
+	ft := reflect.TypeOf(fn)
+	for i := from; i < ft.NumIn(); i++ {
+		pt := ft.In(i)
+		found := false
+		field := newParameterField(i+1, pt)
+		if target, ok := optionalTargetField(field); ok {
+			// an optional parameter is never created as a struct
+			field, found = target, true
+		}
+		if err = s.analyzerFieldDependencies(
+			field,
+			coName,
+			func(asSlice, byName bool, extend string, depCoffins ...*coffin) error {
+				found = true
+				if visit != nil {
+					visit(fmt.Sprintf("parameter %d", i+1), depCoffins)
+				}
+				useDeps, err := s.getUseDeps(depCoffins, nil)
+				deps = append(deps, useDeps...)
+				deps = append(deps, s.getDecoratorDeps(pt, asSlice)...)
+				return err
+			},
+		); err != nil {
+			return nil, err
+		}
+
+		if !found {
+			if pt.Kind() == reflect.Ptr {
+				pt = pt.Elem()
+			}
+			if pt.Kind() == reflect.Struct {
+				var visitField fieldVisitor
+				if visit != nil {
+					visitField = func(name string, coffins []*coffin) { visit(fmt.Sprintf("parameter %d.%s", i+1, name), coffins) }
+				}
+				fieldDeps, err := s.getStructFieldDeps(pt, coName, nil, visitField)
+				if err != nil {
+					return nil, err
+				}
+				deps = append(deps, fieldDeps...)
+			}
+		}
+	}
+	return RemoveRepeat(deps), nil
+}
+
+func (s *dependenceAnalyzer) getPrototypeDeps(co *coffin, prototypePath []*coffin) ([]dependency, error) {
+	for i, p := range prototypePath {
+		if p == co {
+			var circularDeps []dependency
+			for _, c := range append(prototypePath[i:], co) {
+				circularDeps = append(circularDeps, dependency{coffin: c, action: fillAction})
+			}
+			return nil, circularDepsError(circularDeps)
 		}
-		return RemoveRepeat(fillDependencies), nil
-	default:
-		return nil, nil
 	}
+	return s.getFillDepsWithPrototypePath(co, append(prototypePath, co), nil)
 }
 
 func (s *dependenceAnalyzer) analyzerFieldDependencies(
 	field reflect.StructField,
 	coName string,
 	process func(asSlice, byName bool, extend string, coffins ...*coffin) error,
+) error {
+	return s.analyzeFieldDependencies(field, coName, func() {
+		s.logger.Warnf("found multiple value without a default when filling filed %q of %q - using first one.", field.Name, coName)
+	}, process)
+}
+
+// analyzeFieldDependencies is the same as analyzerFieldDependencies, except that warn is called instead of
+// logging a warning when multiple compatible goners are found without a default one.
+func (s *dependenceAnalyzer) analyzeFieldDependencies(
+	field reflect.StructField,
+	coName string,
+	warn func(),
+	process func(asSlice, byName bool, extend string, coffins ...*coffin) error,
 ) error {
 	var tag string
 	var suc bool
@@ -212,9 +373,7 @@ func (s *dependenceAnalyzer) analyzerFieldDependencies(
 	var depCo *coffin
 	var byName bool
 	if strings.Contains(gonerName, "*") || strings.Contains(gonerName, "?") {
-		depCo = s.selectOneCoffin(field.Type, gonerName, func() {
-			s.logger.Warnf("found multiple value without a default when filling filed %q of %q - using first one.", field.Name, coName)
-		})
+		depCo = s.selectOneCoffin(field.Type, gonerName, warn)
 	} else {
 		depCo = s.iKeeper.getByName(gonerName)
 		byName = depCo != nil
@@ -229,12 +388,24 @@ func (s *dependenceAnalyzer) analyzerFieldDependencies(
 		if len(depCos) > 0 {
 			return process(true, byName, extend, depCos...)
 		}
+	} else if field.Type.Kind() == reflect.Map && field.Type.Key().Kind() == reflect.String {
+		// a map is injected like a slice, keyed by the names of goners, so goners without a name are skipped
+		isAllowNil = true
+		var depCos []*coffin
+		for _, co := range s.iKeeper.getByTypeAndPattern(field.Type.Elem(), gonerName) {
+			if co.name != "" {
+				depCos = append(depCos, co)
+			}
+		}
+		if len(depCos) > 0 {
+			return process(true, byName, extend, depCos...)
+		}
 	}
 
 	if !isAllowNil {
 		return NewInnerErrorWithParams(GonerTypeNotMatch,
-			"no compatible value found for field %q of %q",
-			field.Name, coName,
+			"no compatible value found for field %q of %q, %s",
+			field.Name, coName, s.explainRejected(field.Type, gonerName),
 		)
 	}
 	return nil
@@ -245,6 +416,20 @@ func (s *dependenceAnalyzer) checkCircularDepsAndGetBestInitOrder() (circularDep
 	if deps, err = s.collectDeps(); err != nil {
 		return
 	}
+	s.addInitConfigDeps(deps)
 	circularDeps, initOrder = checkCircularDepsAndGetBestInitOrder(deps)
+	if len(circularDeps) > 0 && s.iKeeper.getByName(allowInterfaceCyclesName) != nil {
+		if s.removeDeferredEdges(deps) {
+			circularDeps, initOrder = checkCircularDepsAndGetBestInitOrder(deps)
+		}
+		for len(circularDeps) > 0 && s.breakInterfaceCycle(deps, circularDeps) {
+			circularDeps, initOrder = checkCircularDepsAndGetBestInitOrder(deps)
+		}
+	}
+	s.deps = deps
 	return
 }
+
+func (s *dependenceAnalyzer) getInstallGraph() map[dependency][]dependency {
+	return s.deps
+}
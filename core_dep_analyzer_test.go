@@ -243,6 +243,7 @@ func Test_dependenceAnalyzer_analyzerFieldDependencies(t *testing.T) {
 			name: "process normalField which tag with gone",
 			setUp: func() func() {
 				mockiKeeper.EXPECT().selectOneCoffin(GoneField.Type, "*", gomock.Any()).Return(nil)
+				mockiKeeper.EXPECT().getVisibleCoffins().Return(nil).Times(2)
 				return func() {}
 			},
 			args: args{
@@ -254,7 +255,8 @@ func Test_dependenceAnalyzer_analyzerFieldDependencies(t *testing.T) {
 				},
 			},
 			wantErr: func(err error) bool {
-				return strings.Contains(err.Error(), "no compatible value found for field \"GoneField\" of \"g1\"")
+				return strings.Contains(err.Error(), "no compatible value found for field \"GoneField\" of \"g1\"") &&
+					strings.Contains(err.Error(), "no loaded goner can provide")
 			},
 		},
 		{
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
+	"time"
 )
 
 func newInstaller(iDependenceAnalyzer iDependenceAnalyzer, logger Logger) *installer {
@@ -17,6 +19,25 @@ type installer struct {
 	Flag
 	iDependenceAnalyzer
 	logger Logger `gone:"*"`
+
+	// requestInstances caches instances of request scoped goners, it is nil outside a request scope
+	requestInstances *requestInstances
+
+	// keeper is used to find decorators, decorated caches the decorated values of singletons
+	keeper         iKeeper
+	parent         *installer
+	decoratedMutex sync.Mutex
+	decorated      map[decoratedKey]any
+
+	providedMutex sync.Mutex
+
+	// profiler records calls of providers during the installation, it is nil for child cores
+	profiler *startupProfiler
+}
+
+type decoratedKey struct {
+	co *coffin
+	t  reflect.Type
 }
 
 func (s *installer) injectField(
@@ -27,7 +48,9 @@ func (s *installer) injectField(
 		v = BlackMagic(v)
 	}
 
-	if asSlice {
+	if asSlice && field.Type.Kind() == reflect.Map {
+		return s.injectFieldAsMap(extend, depCoffins, field, v, coName)
+	} else if asSlice {
 		return s.injectFieldAsSlice(extend, depCoffins, field, v, coName)
 	} else {
 		return s.injectFieldAsNotSlice(byName, extend, depCoffins[0], field, v, coName)
@@ -38,7 +61,7 @@ func (s *installer) injectFieldAsSlice(extend string, depCoffins []*coffin, fiel
 	elType := field.Type.Elem()
 	slice := reflect.MakeSlice(field.Type, 0, len(depCoffins))
 	for _, depCo := range depCoffins {
-		if value, err := depCo.Provide(false, extend, elType); err != nil {
+		if value, err := s.provide(depCo, false, extend, elType); err != nil {
 			return ToErrorWithMsg(err, fmt.Sprintf("%q failed to provide value for filed %q element of %q",
 				depCo.Name(), field.Name, coName),
 			)
@@ -50,8 +73,24 @@ func (s *installer) injectFieldAsSlice(extend string, depCoffins []*coffin, fiel
 	return nil
 }
 
+func (s *installer) injectFieldAsMap(extend string, depCoffins []*coffin, field reflect.StructField, v reflect.Value, coName string) error {
+	elType := field.Type.Elem()
+	m := reflect.MakeMapWithSize(field.Type, len(depCoffins))
+	for _, depCo := range depCoffins {
+		if value, err := s.provide(depCo, false, extend, elType); err != nil {
+			return ToErrorWithMsg(err, fmt.Sprintf("%q failed to provide value for filed %q element of %q",
+				depCo.Name(), field.Name, coName),
+			)
+		} else {
+			m.SetMapIndex(reflect.ValueOf(depCo.name).Convert(field.Type.Key()), reflect.ValueOf(value))
+		}
+	}
+	v.Set(m)
+	return nil
+}
+
 func (s *installer) injectFieldAsNotSlice(byName bool, extend string, depCo *coffin, field reflect.StructField, v reflect.Value, coName string) error {
-	if value, err := depCo.Provide(byName, extend, field.Type); err != nil {
+	if value, err := s.provide(depCo, byName, extend, field.Type); err != nil {
 		var e Error
 		if errors.As(err, &e) && e.Code() == NotSupport {
 			if injector, ok := depCo.goner.(StructFieldInjector); ok {
@@ -72,6 +111,143 @@ func (s *installer) injectFieldAsNotSlice(byName bool, extend string, depCo *cof
 	}
 }
 
+// provide returns the value of type t supplied by co. A prototype Goner supplies a new copy of itself,
+// which is filled and initialized before being returned; a request scoped Goner supplies the copy
+// created for the current request scope. The value is wrapped by the decorators of type t, if any.
+func (s *installer) provide(co *coffin, byName bool, extend string, t reflect.Type) (any, error) {
+	if co.prototype && IsCompatible(t, co.goner) {
+		return s.decorate(co, t, false, s.newPrototype)
+	}
+	if co.requestScoped && IsCompatible(t, co.goner) {
+		return s.decorate(co, t, false, s.getRequestInstance)
+	}
+	singleton := co.constructor != nil || IsCompatible(t, co.goner)
+	return s.decorate(co, t, singleton, func(co *coffin) (any, error) {
+		// only calls of providers and constructors are recorded, the goner itself is injected without any work
+		invoked := !IsCompatible(t, co.goner) && (co.provider != nil || co.namedProvider != nil || co.constructor != nil)
+		start := time.Now()
+		v, err := co.Provide(byName, extend, t)
+		if invoked {
+			s.profiler.recordProvide(co, time.Since(start))
+		}
+		if err == nil {
+			s.trackProvided(co, v)
+		}
+		return v, err
+	})
+}
+
+// decorate gets the value supplied by co with get and applies the decorators of type t to it in load order.
+// The decorated value of a singleton is cached, so that every injection point receives the same value.
+// A singleton of a parent container is decorated once by the installer of the parent, with the decorators visible
+// to the parent, and the decorators loaded into this container are applied to that value and cached here.
+func (s *installer) decorate(co *coffin, t reflect.Type, singleton bool, get func(co *coffin) (any, error)) (any, error) {
+	var decorators []*coffin
+	if s.keeper != nil && co.decorator == nil {
+		decorators = s.keeper.getDecorators(t)
+	}
+	if len(decorators) == 0 {
+		return get(co)
+	}
+	if singleton {
+		if owner := s.owner(co); owner != s {
+			local := decorators[len(owner.keeper.getDecorators(t)):]
+			if len(local) == 0 {
+				return owner.decorate(co, t, true, get)
+			}
+			return s.applyDecorators(co, t, true, local, func(co *coffin) (any, error) {
+				return owner.decorate(co, t, true, get)
+			})
+		}
+	}
+	return s.applyDecorators(co, t, singleton, decorators, get)
+}
+
+// owner returns the installer of the container which co is loaded into, or s if it cannot be found.
+func (s *installer) owner(co *coffin) *installer {
+	for i := s; i != nil && i.keeper != nil; i = i.parent {
+		for _, c := range i.keeper.getAllCoffins() {
+			if c == co {
+				return i
+			}
+		}
+	}
+	return s
+}
+
+func (s *installer) applyDecorators(co *coffin, t reflect.Type, singleton bool, decorators []*coffin, get func(co *coffin) (any, error)) (any, error) {
+	key := decoratedKey{co: co, t: t}
+	if singleton {
+		s.decoratedMutex.Lock()
+		v, ok := s.decorated[key]
+		s.decoratedMutex.Unlock()
+		if ok {
+			return v, nil
+		}
+	}
+
+	v, err := get(co)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range decorators {
+		if v, err = d.decorator.decorate(v); err != nil {
+			return nil, ToErrorWithMsg(err, fmt.Sprintf("failed to decorate %s", co.Name()))
+		}
+	}
+
+	if singleton {
+		s.decoratedMutex.Lock()
+		defer s.decoratedMutex.Unlock()
+		if cached, ok := s.decorated[key]; ok {
+			return cached, nil
+		}
+		if s.decorated == nil {
+			s.decorated = make(map[decoratedKey]any)
+		}
+		s.decorated[key] = v
+	}
+	return v, nil
+}
+
+// requestInstances caches the instances of request scoped goners of a request scope, which are shared by its
+// child scopes. created keeps the instances in creation order, so that they are destroyed in reverse order.
+type requestInstances struct {
+	instances map[*coffin]any
+	created   []any
+}
+
+func (s *installer) getRequestInstance(co *coffin) (any, error) {
+	if s.requestInstances == nil {
+		return nil, NewInnerErrorWithParams(NotSupport, "request scoped %s can only be injected in a request scope", co.Name())
+	}
+	if v, ok := s.requestInstances.instances[co]; ok {
+		return v, nil
+	}
+	v, err := s.newPrototype(co)
+	if err != nil {
+		return nil, err
+	}
+	s.requestInstances.instances[co] = v
+	s.requestInstances.created = append(s.requestInstances.created, v)
+	return v, nil
+}
+
+func (s *installer) newPrototype(co *coffin) (any, error) {
+	v := reflect.New(reflect.TypeOf(co.goner).Elem())
+	v.Elem().Set(reflect.ValueOf(co.goner).Elem())
+
+	instance := newCoffin(v.Interface())
+	instance.name = co.name
+	if err := s.safeFillOne(instance); err != nil {
+		return nil, ToErrorWithMsg(err, fmt.Sprintf("failed to fill prototype %s", co.Name()))
+	}
+	if err := s.safeInitOne(instance); err != nil {
+		return nil, ToErrorWithMsg(err, fmt.Sprintf("failed to initialize prototype %s", co.Name()))
+	}
+	return instance.goner, nil
+}
+
 func (s *installer) fillOne(co *coffin) error {
 	if err := s.doBeforeInit(co.goner); err != nil {
 		return err
@@ -89,8 +265,35 @@ func (s *installer) fillOne(co *coffin) error {
 	for i := 0; i < elem.NumField(); i++ {
 		field := elem.Field(i)
 
+		if co.deferredFields[field.Name] {
+			// injected after the value it depends on is initialized
+			continue
+		}
+		if target, ok := lazyTargetField(field); ok {
+			if _, tagged := field.Tag.Lookup(goneTag); tagged {
+				s.injectLazyHandle(target, elemV.Field(i), co.Name())
+				continue
+			}
+		}
+		if target, ok := optionalTargetField(field); ok {
+			if _, tagged := field.Tag.Lookup(goneTag); tagged {
+				from, err := injectOptional(s, target, elemV.Field(i), co.Name())
+				if err != nil {
+					return err
+				}
+				if len(from) > 0 {
+					co.recordInjection(field.Name, from)
+				}
+				continue
+			}
+		}
+
 		injectProcess := func(asSlice, byName bool, extend string, depCoffins ...*coffin) error {
-			return s.injectField(asSlice, byName, extend, depCoffins, field, elemV.Field(i), co.Name())
+			if err := s.injectField(asSlice, byName, extend, depCoffins, field, elemV.Field(i), co.Name()); err != nil {
+				return err
+			}
+			co.recordInjection(field.Name, depCoffins)
+			return nil
 		}
 
 		if err := s.iDependenceAnalyzer.analyzerFieldDependencies(field, co.Name(), injectProcess); err != nil {
@@ -123,6 +326,25 @@ func (s *installer) safeFillOne(c *coffin) error {
 }
 
 func (s *installer) safeInitOne(c *coffin) error {
+	if err := s.applyInitConfig(c); err != nil {
+		return err
+	}
+	if c.initPolicy.enabled() {
+		return s.initWithPolicy(c)
+	}
+	return s.initOnce(c)
+}
+
+func (s *installer) initOnce(c *coffin) error {
+	if err := s.callInit(c); err != nil {
+		return err
+	}
+	c.isInit = true
+	return nil
+}
+
+// callInit calls the Init method of c, without marking c initialized.
+func (s *installer) callInit(c *coffin) error {
 	return SafeExecute(func() error {
 		goner := c.goner
 		if initiator, ok := goner.(InitiatorNoError); ok {
@@ -133,7 +355,6 @@ func (s *installer) safeInitOne(c *coffin) error {
 				return ToError(err)
 			}
 		}
-		c.isInit = true
 		return nil
 	})
 }
@@ -99,6 +99,46 @@ func Test_installer_injectFieldAsSlice(t *testing.T) {
 	}
 }
 
+func Test_installer_injectFieldAsMap(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	analyzer := NewMockiDependenceAnalyzer(controller)
+	logger := NewMockLogger(controller)
+
+	ins := newInstaller(analyzer, logger)
+
+	type g struct {
+		Flag
+	}
+	type key string
+
+	var x struct {
+		Flag
+		Map map[key]any `gone:"*"`
+	}
+	field, _ := reflect.TypeOf(&x).Elem().FieldByName("Map")
+	v := reflect.ValueOf(&x).Elem().FieldByName("Map")
+
+	errCo := newCoffin(&g1Provider{err: errors.New("err")})
+	errCo.name = "err"
+	errField := reflect.StructField{Type: reflect.TypeOf(map[string]*g1{})}
+	if err := ins.injectFieldAsMap("", []*coffin{errCo}, errField, reflect.ValueOf(map[string]*g1{}), "test-goner"); err == nil {
+		t.Errorf("injectFieldAsMap() should return error of provider")
+	}
+
+	co1 := newCoffin(&g1Provider{g1: &g1{}})
+	co1.name = "g1"
+	co2 := newCoffin(&g{})
+	co2.name = "g"
+	if err := ins.injectFieldAsMap("", []*coffin{co1, co2}, field, v, "test-goner"); err != nil {
+		t.Errorf("injectFieldAsMap() error = %v", err)
+	}
+	if len(x.Map) != 2 || x.Map["g"] != co2.goner {
+		t.Errorf("values should be keyed by goner name, got %v", x.Map)
+	}
+}
+
 var _ StructFieldInjector = (*testInjector)(nil)
 
 type testInjector struct {
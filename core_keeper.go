@@ -10,8 +10,17 @@ func newKeeper() *keeper {
 	}
 }
 
+// newChildKeeper creates a keeper which falls back to parent when a goner cannot be found in itself.
+// Goners loaded into the child shadow goners of the parent with the same name or type.
+func newChildKeeper(parent iKeeper) *keeper {
+	k := newKeeper()
+	k.parent = parent
+	return k
+}
+
 type keeper struct {
 	Flag
+	parent         iKeeper
 	coffins        []*coffin
 	nameMap        map[string]*coffin
 	defaultTypeMap map[reflect.Type]*coffin
@@ -21,11 +30,43 @@ func (s *keeper) getAllCoffins() []*coffin {
 	return s.coffins
 }
 
+// getVisibleCoffins returns the coffins of the keeper followed by the coffins of the parent, the ones of the parent
+// shadowed by a goner with the same name in the keeper are left out.
+func (s *keeper) getVisibleCoffins() []*coffin {
+	if s.parent == nil {
+		return s.coffins
+	}
+	coffins := append([]*coffin{}, s.coffins...)
+	for _, co := range s.parent.getVisibleCoffins() {
+		if _, shadowed := s.nameMap[co.name]; co.name != "" && shadowed {
+			continue
+		}
+		coffins = append(coffins, co)
+	}
+	return coffins
+}
+
 func (s *keeper) getByName(name string) *coffin {
-	return s.nameMap[name]
+	if co, ok := s.nameMap[name]; ok || s.parent == nil {
+		return co
+	}
+	return s.parent.getByName(name)
 }
 
 func (s *keeper) getByTypeAndPattern(t reflect.Type, pattern string) (coffins []*coffin) {
+	coffins = s.getLocalByTypeAndPattern(t, pattern)
+	if s.parent != nil {
+		for _, co := range s.parent.getByTypeAndPattern(t, pattern) {
+			if _, shadowed := s.nameMap[co.name]; co.name != "" && shadowed {
+				continue
+			}
+			coffins = append(coffins, co)
+		}
+	}
+	return coffins
+}
+
+func (s *keeper) getLocalByTypeAndPattern(t reflect.Type, pattern string) (coffins []*coffin) {
 	for _, co := range s.coffins {
 		if co.onlyForName {
 			continue
@@ -40,8 +81,59 @@ func (s *keeper) getByTypeAndPattern(t reflect.Type, pattern string) (coffins []
 	return coffins
 }
 
+// remove removes co from the keeper, so that it is neither installed nor injected.
+func (s *keeper) remove(co *coffin) {
+	for i := range s.coffins {
+		if s.coffins[i] == co {
+			s.coffins = append(s.coffins[:i], s.coffins[i+1:]...)
+			break
+		}
+	}
+	if co.name != "" && s.nameMap[co.name] == co {
+		delete(s.nameMap, co.name)
+	}
+	for t, typeCo := range s.defaultTypeMap {
+		if typeCo == co {
+			delete(s.defaultTypeMap, t)
+		}
+	}
+}
+
+// getDecorators returns the coffins of decorators registered for type t, decorators of the parent come first.
+func (s *keeper) getDecorators(t reflect.Type) (decorators []*coffin) {
+	if s.parent != nil {
+		decorators = s.parent.getDecorators(t)
+	}
+	for _, co := range s.coffins {
+		if co.decorator != nil && co.decorator.t == t {
+			decorators = append(decorators, co)
+		}
+	}
+	return decorators
+}
+
+// withoutFallback removes the fallback coffins if there is any other coffin.
+func withoutFallback(coffins []*coffin) []*coffin {
+	var others []*coffin
+	for _, co := range coffins {
+		if !co.fallback {
+			others = append(others, co)
+		}
+	}
+	if len(others) == 0 {
+		return coffins
+	}
+	return others
+}
+
 func (s *keeper) selectOneCoffin(t reflect.Type, pattern string, warn func()) (depCo *coffin) {
-	if depCos := s.getByTypeAndPattern(t, pattern); depCos != nil && len(depCos) > 0 {
+	depCos := withoutFallback(s.getLocalByTypeAndPattern(t, pattern))
+	if s.parent != nil && (len(depCos) == 0 || depCos[0].fallback) {
+		if parentCo := s.parent.selectOneCoffin(t, pattern, warn); parentCo != nil && (len(depCos) == 0 || !parentCo.fallback) {
+			return parentCo
+		}
+	}
+	if len(depCos) > 0 {
 		l := len(depCos)
 		if l == 1 {
 			depCo = depCos[0]
@@ -75,16 +167,21 @@ func (s *keeper) load(goner Goner, options ...Option) error {
 		}
 	}
 
+	// a fallback goner is replaced by any other goner with the same name, whichever is loaded first
+	var replace = co.forceReplace
 	if co.name != "" {
-		if _, ok := s.nameMap[co.name]; ok && !co.forceReplace {
+		if old, ok := s.nameMap[co.name]; ok && !co.forceReplace && co.fallback && !old.fallback {
+			return nil
+		} else if ok && !co.forceReplace && !(old.fallback && !co.fallback) {
 			return NewInnerErrorWithParams(LoadedError, "goner with name %q is already loaded - use ForceReplace() option to override", co.name)
 		} else {
+			replace = replace || ok
 			s.nameMap[co.name] = co
 		}
 	}
 
 	var forceReplaceFind = false
-	if co.forceReplace && co.name != "" {
+	if replace && co.name != "" {
 		var replacedCo *coffin
 		for i := range s.coffins {
 			if s.coffins[i].name == co.name {
@@ -106,7 +203,10 @@ func (s *keeper) load(goner Goner, options ...Option) error {
 	}
 
 	for t := range co.defaultTypeMap {
-		if _, ok := s.defaultTypeMap[t]; ok {
+		if old, ok := s.defaultTypeMap[t]; ok && co.fallback && !old.fallback {
+			// the fallback yields to the default loaded before it
+			continue
+		} else if ok && !(old.fallback && !co.fallback) {
 			return NewInnerErrorWithParams(
 				LoadedError,
 				"type %q is already registered as default - cannot use IsDefault option when Loading named provider: %q",
@@ -146,6 +146,54 @@ func Test_keeper_load(t *testing.T) {
 	}
 }
 
+func Test_keeper_load_fallback(t *testing.T) {
+	type g struct {
+		Flag
+		Name string
+	}
+
+	s := newKeeper()
+	_ = s.load(&g{Name: "fallback"}, Name("food-01"), IsDefault(), IsFallback())
+
+	if err := s.load(&g{Name: "fallback-2"}, Name("food-01"), IsFallback()); err == nil {
+		t.Error("a fallback should not replace another fallback")
+	}
+	if err := s.load(&g{Name: "other"}, Name("food-01"), IsDefault()); err != nil {
+		t.Errorf("a fallback should be replaced without ForceReplace, error = %v", err)
+	}
+
+	coffins := s.getByTypeAndPattern(reflect.TypeOf(&g{}), "*")
+	if len(coffins) != 1 || coffins[0].goner.(*g).Name != "other" || s.getByName("food-01") != coffins[0] {
+		t.Errorf("the fallback should be replaced")
+	}
+}
+
+func Test_keeper_load_fallback_after_other(t *testing.T) {
+	type g struct {
+		Flag
+		Name string
+	}
+
+	s := newKeeper()
+	if err := s.load(&g{Name: "other"}, Name("food-01"), IsDefault()); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.load(&g{Name: "fallback"}, Name("food-01"), IsDefault(), IsFallback()); err != nil {
+		t.Errorf("a fallback loaded later should yield, error = %v", err)
+	}
+	if err := s.load(&g{Name: "unnamed-fallback"}, IsDefault(), IsFallback()); err != nil {
+		t.Errorf("a fallback default loaded later should yield, error = %v", err)
+	}
+
+	coffins := s.getByTypeAndPattern(reflect.TypeOf(&g{}), "food-01")
+	if len(coffins) != 1 || coffins[0].goner.(*g).Name != "other" || s.getByName("food-01") != coffins[0] {
+		t.Errorf("the goner loaded first should be kept")
+	}
+	if co := s.selectOneCoffin(reflect.TypeOf(&g{}), "*", nil); co == nil || co.goner.(*g).Name != "other" {
+		t.Errorf("the goner loaded first should stay the default")
+	}
+}
+
 func Test_keeper_selectOneCoffin(t *testing.T) {
 
 	var k *keeper
@@ -209,6 +257,60 @@ func Test_keeper_selectOneCoffin(t *testing.T) {
 			},
 			gonerName: "name-10",
 		},
+		{
+			name: "fallback yields to other coffin, even the default one",
+			setUp: func() func() {
+				k = newKeeper()
+				_ = k.load(&X{ID: 10}, Name("name-10"), IsDefault(), IsFallback())
+				_ = k.load(&X{ID: 20}, Name("name-11"))
+
+				return func() {}
+			},
+			args: args{
+				t:         reflect.TypeOf(&X{}),
+				gonerName: "*",
+				warn: func() {
+					t.Errorf("should not warn")
+				},
+			},
+			gonerName: "name-11",
+		},
+		{
+			name: "fallback of child yields to parent",
+			setUp: func() func() {
+				parent := newKeeper()
+				_ = parent.load(&X{ID: 10}, Name("name-10"))
+				k = newChildKeeper(parent)
+				_ = k.load(&X{ID: 20}, Name("name-11"), IsFallback())
+
+				return func() {}
+			},
+			args: args{
+				t:         reflect.TypeOf(&X{}),
+				gonerName: "*",
+				warn: func() {
+					t.Errorf("should not warn")
+				},
+			},
+			gonerName: "name-10",
+		},
+		{
+			name: "only fallback",
+			setUp: func() func() {
+				k = newKeeper()
+				_ = k.load(&X{ID: 10}, Name("name-10"), IsFallback())
+
+				return func() {}
+			},
+			args: args{
+				t:         reflect.TypeOf(&X{}),
+				gonerName: "*",
+				warn: func() {
+					t.Errorf("should not warn")
+				},
+			},
+			gonerName: "name-10",
+		},
 		{
 			name: "multi coffin without default",
 			setUp: func() func() {
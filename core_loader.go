@@ -21,10 +21,14 @@ import "fmt"
 // Available Options:
 //   - Name(name string): Set custom name for the Goner
 //   - IsDefault(): Mark this Goner as the default implementation
+//   - IsFallback(): Mark this Goner as a fallback, which yields to any other implementation
 //   - OnlyForName(): Only register by name, not as provider
 //   - ForceReplace(): Replace existing Goner with same name/type
 //   - Order(order int): Set initialization order (lower runs first)
 //   - FillWhenInit(): Fill dependencies during initialization
+//   - OnConfig(key, value), OnMissingType(objPointer), OnPresentName(name): Load only when the condition holds,
+//     conditions are evaluated when the Core is installed
+//   - Profile(profiles...): Load only when one of the profiles is active
 //
 // Returns error if:
 //   - Any option.Apply() fails
@@ -34,6 +38,26 @@ func (s *core) Load(goner Goner, options ...Option) error {
 	return s.iKeeper.load(goner, options...)
 }
 
+// LoadConstructor registers a constructor function into the Core with optional configuration options.
+//
+// The constructor can be any function returning a value, or a value and an error. Its parameters are analyzed as
+// dependencies, so it is called during installation after everything it needs has been initialized, and the
+// returned value can be injected wherever its type (or an interface it implements) is required. This makes it
+// possible to register third-party types which do not embed gone.Flag.
+//
+// Example usage:
+//
+//	loader.LoadConstructor(func(conf *Config, logger gone.Logger) (*sql.DB, error) {
+//	    return sql.Open("mysql", conf.DSN)
+//	}, gone.Name("db"))
+func (s *core) LoadConstructor(fn any, options ...Option) error {
+	c, err := newConstructor(fn)
+	if err != nil {
+		return err
+	}
+	return s.iKeeper.load(c, options...)
+}
+
 // MustLoad is similar to Load but panics if an error occurs during loading.
 // This provides a more convenient way to load components when you expect the operation to succeed.
 //
@@ -111,11 +135,19 @@ func (s *core) MustLoadX(x any) Loader {
 //
 // Note: If the component hasn't been loaded, it will be marked as loaded before returning false.
 // This ensures that subsequent calls with the same key will return true.
+// For a child container, a component loaded by any of its parents is also treated as loaded.
 func (s *core) Loaded(key LoaderKey) bool {
-	if _, ok := s.loaderMap[key]; ok {
+	if s.hasLoaded(key) {
 		return true
 	} else {
 		s.loaderMap[key] = struct{}{}
 		return false
 	}
 }
+
+func (s *core) hasLoaded(key LoaderKey) bool {
+	if _, ok := s.loaderMap[key]; ok {
+		return true
+	}
+	return s.parent != nil && s.parent.hasLoaded(key)
+}
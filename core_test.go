@@ -496,6 +496,7 @@ func Test_core_Install(t *testing.T) {
 	mockiKeeper := NewMockiKeeper(controller)
 	logger := NewMockLogger(controller)
 	mockiInstaller := NewMockiInstaller(controller)
+	mockiKeeper.EXPECT().getByName(gomock.Any()).Return(nil).AnyTimes()
 
 	s := &core{
 		logger:              logger,
@@ -516,6 +517,7 @@ func Test_core_Install(t *testing.T) {
 		{
 			name: "err",
 			setUp: func() func() {
+				mockiKeeper.EXPECT().getAllCoffins().Return(nil)
 				analyzer.EXPECT().checkCircularDepsAndGetBestInitOrder().Return(nil, nil, errors.New("err"))
 				return func() {}
 			},
@@ -534,7 +536,7 @@ func Test_core_Install(t *testing.T) {
 
 				mockiKeeper.EXPECT().getAllCoffins().Return([]*coffin{
 					newCoffin(&x),
-				})
+				}).Times(2)
 				mockiInstaller.EXPECT().safeFillOne(gomock.Any()).Return(nil)
 				mockiInstaller.EXPECT().safeInitOne(gomock.Any()).Return(nil)
 				return func() {}
@@ -554,7 +556,7 @@ func Test_core_Install(t *testing.T) {
 
 				mockiKeeper.EXPECT().getAllCoffins().Return([]*coffin{
 					newCoffin(&x),
-				})
+				}).Times(2)
 				mockiInstaller.EXPECT().safeInitOne(gomock.Any()).Return(errors.New("err"))
 
 				return func() {}
@@ -574,7 +576,7 @@ func Test_core_Install(t *testing.T) {
 
 				mockiKeeper.EXPECT().getAllCoffins().Return([]*coffin{
 					newCoffin(&x),
-				})
+				}).Times(2)
 				mockiInstaller.EXPECT().safeFillOne(gomock.Any()).Return(errors.New("err"))
 				mockiInstaller.EXPECT().safeInitOne(gomock.Any()).Return(nil)
 
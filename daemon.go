@@ -0,0 +1,99 @@
+package gone
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ShutdownTimeoutKey is the configuration key of the time to wait for daemons to stop, like "30s".
+// When it is not set or is zero, the application waits for daemons to stop without a deadline.
+const ShutdownTimeoutKey = "gone.shutdown.timeout"
+
+// daemonRunner adapts a Daemon or a ContextDaemon, so that both are started and stopped in the same way.
+type daemonRunner struct {
+	name  string
+	start func(ctx context.Context) error
+	stop  func(ctx context.Context) error
+}
+
+// daemonRunners returns the Daemons and ContextDaemons loaded into the Application, in order of Order() value
+// and then loading order. Goners loaded by gone itself are not daemons.
+func (s *Application) daemonRunners() []daemonRunner {
+	var coffins []*coffin
+	for _, co := range s.loader.iKeeper.getAllCoffins() {
+		if co.builtin || co.prototype || co.requestScoped {
+			continue
+		}
+		switch co.goner.(type) {
+		case Daemon, ContextDaemon:
+			coffins = append(coffins, co)
+		}
+	}
+	SortCoffins(coffins)
+
+	runners := make([]daemonRunner, 0, len(coffins))
+	for _, co := range coffins {
+		name := GetTypeName(reflect.TypeOf(co.goner))
+		switch d := co.goner.(type) {
+		case Daemon:
+			runners = append(runners, daemonRunner{
+				name:  name,
+				start: func(context.Context) error { return d.Start() },
+				stop:  func(context.Context) error { return d.Stop() },
+			})
+		case ContextDaemon:
+			runners = append(runners, daemonRunner{name: name, start: d.Start, stop: d.Stop})
+		}
+	}
+	return runners
+}
+
+// stopDaemons stops the daemons in reverse order, and returns the errors of all daemons failed to stop.
+// When ctx is done before a daemon stops, the daemon and all the daemons not stopped yet are abandoned,
+// and an error listing them is returned as the last one.
+func stopDaemons(ctx context.Context, runners []daemonRunner) (errs []error) {
+	for i := len(runners) - 1; i >= 0; i-- {
+		done := make(chan error, 1)
+		go func(r daemonRunner) {
+			done <- SafeExecute(func() error {
+				return r.stop(ctx)
+			})
+		}(runners[i])
+
+		select {
+		case err := <-done:
+			if err != nil {
+				errs = append(errs, ToErrorWithMsg(err, "failed to stop daemon "+runners[i].name))
+			}
+		case <-ctx.Done():
+			var abandoned []string
+			for j := i - 1; j >= 0; j-- {
+				abandoned = append(abandoned, runners[j].name)
+			}
+			msg := "daemon " + runners[i].name + " hung, it did not stop before the shutdown timeout"
+			if len(abandoned) > 0 {
+				msg += ", daemons not stopped: " + strings.Join(abandoned, ", ")
+			}
+			return append(errs, NewInnerError(msg, ShutdownTimeout))
+		}
+	}
+	return errs
+}
+
+// shutdownContext returns the context passed to ContextDaemon.Stop, which is done when ctx is done
+// or the shutdown timeout expires.
+func (s *Application) shutdownContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.shutdownTimeout == "" {
+		return context.WithCancel(ctx)
+	}
+	timeout, err := time.ParseDuration(s.shutdownTimeout)
+	if err != nil {
+		s.loader.logger.Warnf("invalid %s %q, waiting for daemons to stop without a deadline", ShutdownTimeoutKey, s.shutdownTimeout)
+	}
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
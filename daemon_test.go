@@ -0,0 +1,149 @@
+package gone
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type ctxDaemon struct {
+	Flag
+	name    string
+	log     *[]string
+	block   chan struct{}
+	stopErr error
+}
+
+func (d *ctxDaemon) Start(ctx context.Context) error {
+	*d.log = append(*d.log, "start "+d.name)
+	return nil
+}
+
+func (d *ctxDaemon) Stop(ctx context.Context) error {
+	if d.block != nil {
+		<-d.block
+	}
+	*d.log = append(*d.log, "stop "+d.name)
+	return d.stopErr
+}
+
+type plainDaemon struct {
+	Flag
+	log *[]string
+}
+
+func (d *plainDaemon) Start() error {
+	*d.log = append(*d.log, "start plain")
+	return nil
+}
+
+func (d *plainDaemon) Stop() error {
+	*d.log = append(*d.log, "stop plain")
+	return nil
+}
+
+func TestContextDaemon(t *testing.T) {
+	t.Run("start and stop in order", func(t *testing.T) {
+		var log []string
+		NewApp().
+			Load(&ctxDaemon{name: "b", log: &log}, Order(2)).
+			Load(&plainDaemon{log: &log}, Order(1)).
+			Load(&ctxDaemon{name: "a", log: &log}, Order(1)).
+			Run()
+
+		want := "start plain,start a,start b,stop b,stop a,stop plain"
+		if got := strings.Join(log, ","); got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("stop error", func(t *testing.T) {
+		var log []string
+		defer func() {
+			err, _ := recover().(error)
+			if err == nil || !strings.Contains(err.Error(), "stop failed") {
+				t.Errorf("unexpected panic %v", err)
+			}
+		}()
+		NewApp().
+			Load(&ctxDaemon{name: "a", log: &log, stopErr: errors.New("stop failed")}).
+			Run()
+	})
+
+	t.Run("shutdown timeout", func(t *testing.T) {
+		t.Setenv("GONE_GONE_SHUTDOWN_TIMEOUT", "50ms")
+		var log []string
+		block := make(chan struct{})
+		defer close(block)
+		defer func() {
+			var e MultiError
+			err, _ := recover().(error)
+			if !errors.As(err, &e) || e.Code() != StopError || len(e.Errors()) != 1 {
+				t.Fatalf("unexpected panic %v", err)
+			}
+			var timeout Error
+			if !errors.As(e.Errors()[0], &timeout) || timeout.Code() != ShutdownTimeout {
+				t.Fatalf("unexpected error %v", e.Errors()[0])
+			}
+			msg := timeout.Error()
+			if !strings.Contains(msg, "ctxDaemon hung") || !strings.Contains(msg, "daemons not stopped: *github.com/gone-io/gone/v2.plainDaemon") {
+				t.Errorf("unexpected error %s", msg)
+			}
+		}()
+		NewApp().
+			Load(&plainDaemon{log: &log}).
+			Load(&ctxDaemon{name: "a", log: &log, block: block}).
+			Run()
+	})
+}
+
+func Test_stopDaemons(t *testing.T) {
+	t.Run("continue after errors", func(t *testing.T) {
+		var stopped []string
+		errs := stopDaemons(context.Background(), []daemonRunner{
+			{name: "first", stop: func(context.Context) error {
+				stopped = append(stopped, "first")
+				return nil
+			}},
+			{name: "second", stop: func(context.Context) error {
+				stopped = append(stopped, "second")
+				return errors.New("second failed")
+			}},
+			{name: "third", stop: func(context.Context) error {
+				panic("third panicked")
+			}},
+		})
+		if len(errs) != 2 || !strings.Contains(errs[0].Error(), "third panicked") || !strings.Contains(errs[1].Error(), "second failed") {
+			t.Errorf("unexpected errors %v", errs)
+		}
+		if strings.Join(stopped, ",") != "second,first" {
+			t.Errorf("all daemons should be stopped, got %v", stopped)
+		}
+	})
+
+	t.Run("abandon after timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		stopped := false
+		errs := stopDaemons(ctx, []daemonRunner{
+			{name: "first", stop: func(context.Context) error {
+				stopped = true
+				return nil
+			}},
+			{name: "second", stop: func(ctx context.Context) error {
+				<-ctx.Done()
+				time.Sleep(10 * time.Millisecond)
+				return nil
+			}},
+		})
+		if len(errs) != 1 || !strings.Contains(errs[0].Error(), "daemon second hung") || !strings.Contains(errs[0].Error(), "first") {
+			t.Errorf("unexpected errors %v", errs)
+		}
+		if stopped {
+			t.Error("daemons after the hung one should be abandoned")
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package gone
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// decorator is the Goner created by Decorate. It wraps every value resolved for type t before the value is injected.
+type decorator struct {
+	Flag
+	injector FuncInjector `gone:"*"`
+
+	fn any
+	t  reflect.Type
+}
+
+func newDecorator(t reflect.Type, fn any) (*decorator, error) {
+	if fn == nil {
+		return nil, NewInnerError("decorator cannot be nil - must provide a function", LoadedError)
+	}
+	ft := reflect.TypeOf(fn)
+	if ft.Kind() != reflect.Func {
+		return nil, NewInnerErrorWithParams(LoadedError, "decorator must be a function, got %T", fn)
+	}
+	if ft.IsVariadic() || ft.NumIn() == 0 || ft.In(0) != t {
+		return nil, NewInnerErrorWithParams(LoadedError,
+			"decorator %s must be a non-variadic function whose first parameter is %s", GetFuncName(fn), GetTypeName(t),
+		)
+	}
+	if !(ft.NumOut() == 1 || ft.NumOut() == 2 && ft.Out(1) == errType) || ft.Out(0) != t {
+		return nil, NewInnerErrorWithParams(LoadedError,
+			"decorator %s must return %s, or %s and an error", GetFuncName(fn), GetTypeName(t), GetTypeName(t),
+		)
+	}
+	return &decorator{fn: fn, t: t}, nil
+}
+
+func (d *decorator) funcName() string {
+	return GetFuncName(d.fn)
+}
+
+// decorate calls the decorator function with v as the first parameter and the other parameters injected.
+func (d *decorator) decorate(v any) (any, error) {
+	f, err := d.injector.InjectWrapFunc(d.fn, func(pt reflect.Type, i int, injected bool) any {
+		if i == 0 {
+			return v
+		}
+		return nil
+	}, nil)
+	if err != nil {
+		return nil, ToErrorWithMsg(err, fmt.Sprintf("failed to inject parameters of decorator %s", d.funcName()))
+	}
+	results := f()
+	if len(results) == 2 && results[1] != nil {
+		return nil, ToErrorWithMsg(results[1], fmt.Sprintf("decorator %s returned error", d.funcName()))
+	}
+	if results[0] == nil {
+		return nil, NewInnerErrorWithParams(ProviderError, "decorator %s returned nil", d.funcName())
+	}
+	return results[0], nil
+}
+
+// Decorate creates a Goner which wraps every value resolved for type T before it is injected into struct fields
+// and function parameters, for adding logging, metrics, retries or caching around a component without replacing it.
+//
+// The fn must be a function whose first parameter is the resolved value of type T, and which returns the wrapped
+// value of type T, or the wrapped value and an error. The other parameters of fn are injected like the parameters
+// of a constructor. When several decorators are loaded for the same type, they are applied in load order, so the
+// last loaded one is the outermost; decorators loaded into a parent are applied before those loaded into a Scope.
+// The value is decorated once for every Goner, except prototype and request scoped Goners which are decorated
+// every time they are created; a Goner of a parent is decorated once by the decorators of the parent, and each
+// Scope applies its own decorators to that value once.
+//
+// Decorate panics if fn is not a valid decorator function of type T.
+//
+// Example usage:
+//
+//	app.Load(gone.Decorate[Service](func(s Service, logger gone.Logger) Service {
+//	    return &loggingService{Service: s, logger: logger}
+//	}))
+func Decorate[T any](fn any) Goner {
+	d, err := newDecorator(reflect.TypeOf((*T)(nil)).Elem(), fn)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
@@ -0,0 +1,212 @@
+package gone
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type decoratedService interface {
+	Name() string
+}
+
+type decoratedServiceImpl struct {
+	Flag
+	name string
+}
+
+func (s *decoratedServiceImpl) Name() string {
+	return s.name
+}
+
+type decoratedServiceWrapper struct {
+	decoratedService
+	prefix string
+}
+
+func (s *decoratedServiceWrapper) Name() string {
+	return s.prefix + s.decoratedService.Name()
+}
+
+func Test_newDecorator(t *testing.T) {
+	tp := GetInterfaceType(new(decoratedService))
+
+	tests := []struct {
+		name    string
+		fn      any
+		wantErr bool
+	}{
+		{name: "nil", fn: nil, wantErr: true},
+		{name: "not function", fn: 1, wantErr: true},
+		{name: "no parameter", fn: func() decoratedService { return nil }, wantErr: true},
+		{name: "first parameter is not T", fn: func(Logger) decoratedService { return nil }, wantErr: true},
+		{name: "variadic", fn: func(decoratedService, ...int) decoratedService { return nil }, wantErr: true},
+		{name: "return is not T", fn: func(decoratedService) *decoratedServiceImpl { return nil }, wantErr: true},
+		{name: "second return is not error", fn: func(decoratedService) (decoratedService, int) { return nil, 0 }, wantErr: true},
+		{name: "value only", fn: func(s decoratedService) decoratedService { return s }, wantErr: false},
+		{name: "value and error", fn: func(s decoratedService, _ Logger) (decoratedService, error) { return s, nil }, wantErr: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newDecorator(tp, tt.fn); (err != nil) != tt.wantErr {
+				t.Errorf("newDecorator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecorate(t *testing.T) {
+	t.Run("panic for invalid function", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Decorate should panic for invalid function")
+			}
+		}()
+		Decorate[decoratedService](func() {})
+	})
+
+	t.Run("decorators are applied in load order", func(t *testing.T) {
+		var calls int
+		NewApp().
+			Load(&decoratedServiceImpl{name: "svc"}).
+			Load(Decorate[decoratedService](func(s decoratedService) decoratedService {
+				calls++
+				return &decoratedServiceWrapper{decoratedService: s, prefix: "a."}
+			})).
+			Load(Decorate[decoratedService](func(s decoratedService, logger Logger) (decoratedService, error) {
+				if logger == nil {
+					t.Error("parameters of decorator should be injected")
+				}
+				return &decoratedServiceWrapper{decoratedService: s, prefix: "b."}, nil
+			})).
+			Run(func(s1 decoratedService, s2 decoratedService, impl *decoratedServiceImpl) {
+				if s1.Name() != "b.a.svc" {
+					t.Errorf("unexpected name %q", s1.Name())
+				}
+				if s1 != s2 || calls != 1 {
+					t.Error("the decorated value of a singleton should be cached")
+				}
+				if impl.Name() != "svc" {
+					t.Error("values of other types should not be decorated")
+				}
+			})
+	})
+
+	t.Run("decorator returns error", func(t *testing.T) {
+		app := NewApp().
+			Load(&decoratedServiceImpl{name: "svc"}).
+			Load(Decorate[decoratedService](func(s decoratedService) (decoratedService, error) {
+				return nil, errors.New("decorate failed")
+			}))
+		if err := app.loader.Install(); err != nil {
+			t.Fatal(err)
+		}
+		err := app.loader.InjectStruct(&struct {
+			s decoratedService `gone:"*"`
+		}{})
+		if err == nil || !strings.Contains(err.Error(), "decorate failed") {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+
+	t.Run("decorator returns nil", func(t *testing.T) {
+		app := NewApp().
+			Load(&decoratedServiceImpl{name: "svc"}).
+			Load(Decorate[decoratedService](func(s decoratedService) decoratedService {
+				return nil
+			}))
+		if err := app.loader.Install(); err != nil {
+			t.Fatal(err)
+		}
+		err := app.loader.InjectStruct(&struct {
+			s decoratedService `gone:"*"`
+		}{})
+		if err == nil || !strings.Contains(err.Error(), "returned nil") {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+
+	t.Run("prototypes are decorated every time", func(t *testing.T) {
+		var calls int
+		NewApp().
+			Load(&decoratedServiceImpl{name: "svc"}, Prototype()).
+			Load(Decorate[decoratedService](func(s decoratedService) decoratedService {
+				calls++
+				return &decoratedServiceWrapper{decoratedService: s, prefix: "a."}
+			})).
+			Run(func(s1 decoratedService, s2 decoratedService) {
+				if s1 == s2 || calls != 2 {
+					t.Error("each prototype instance should be decorated")
+				}
+			})
+	})
+
+	t.Run("decorators of parent are applied in scope", func(t *testing.T) {
+		app := NewApp().
+			Load(Decorate[decoratedService](func(s decoratedService) decoratedService {
+				return &decoratedServiceWrapper{decoratedService: s, prefix: "parent."}
+			}))
+		app.Run(func() {
+			err := app.NewScope().
+				Load(&decoratedServiceImpl{name: "svc"}).
+				Load(Decorate[decoratedService](func(s decoratedService) decoratedService {
+					return &decoratedServiceWrapper{decoratedService: s, prefix: "scope."}
+				})).
+				Run(func(s decoratedService) {
+					if s.Name() != "scope.parent.svc" {
+						t.Errorf("unexpected name %q", s.Name())
+					}
+				})
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	})
+
+	t.Run("singleton of parent is decorated once", func(t *testing.T) {
+		var parentCalls, scopeCalls int
+		app := NewApp().
+			Load(&decoratedServiceImpl{name: "svc"}).
+			Load(Decorate[decoratedService](func(s decoratedService) decoratedService {
+				parentCalls++
+				return &decoratedServiceWrapper{decoratedService: s, prefix: "parent."}
+			}))
+		app.Run(func(parent decoratedService) {
+			for i := 0; i < 2; i++ {
+				scope := app.NewScope().
+					Load(Decorate[decoratedService](func(s decoratedService) decoratedService {
+						scopeCalls++
+						return &decoratedServiceWrapper{decoratedService: s, prefix: "scope."}
+					}))
+				err := scope.Run(func(s1, s2 decoratedService) {
+					if s1 != s2 || s1.Name() != "scope.parent.svc" {
+						t.Errorf("unexpected decorated value %q", s1.Name())
+					}
+				})
+				if err != nil {
+					t.Error(err)
+				}
+				_ = scope.Close()
+
+				err = app.NewScope().Run(func(s decoratedService) {
+					if s != parent {
+						t.Error("scope without decorators should get the value decorated by the parent")
+					}
+				})
+				if err != nil {
+					t.Error(err)
+				}
+			}
+		})
+		if parentCalls != 1 || scopeCalls != 2 {
+			t.Errorf("parent decorator called %d times, scope decorators called %d times", parentCalls, scopeCalls)
+		}
+	})
+
+	t.Run("coffin name", func(t *testing.T) {
+		co := newCoffin(Decorate[decoratedService](func(s decoratedService) decoratedService { return s }))
+		if !co.needInitBeforeUse || co.decorator == nil || !strings.HasPrefix(co.Name(), "Decorator(") {
+			t.Errorf("unexpected decorator coffin %q", co.Name())
+		}
+	})
+}
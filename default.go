@@ -24,6 +24,17 @@ func Load(goner Goner, options ...Option) *Application {
 	return Default.Load(goner, options...)
 }
 
+// LoadConstructor uses the default application instance to register a constructor function with optional configuration options.
+// Parameters:
+//   - fn: A function like func(deps...) (*T, error), whose parameters are injected
+//   - options: Optional configuration options
+//
+// Returns:
+//   - *Application: Returns the default application instance for method chaining
+func LoadConstructor(fn any, options ...Option) *Application {
+	return Default.LoadConstructor(fn, options...)
+}
+
 // Run executes one or more functions using the default application instance.
 // These functions can have dependencies that will be automatically injected.
 // Parameters:
@@ -38,6 +49,16 @@ func Serve(fn ...any) {
 	Default.Serve(fn...)
 }
 
+// RunE is like Run, but returns errors instead of panicking, see Application.RunE.
+func RunE(fn ...any) error {
+	return Default.RunE(fn...)
+}
+
+// ServeE is like Serve, but returns errors instead of panicking, see Application.ServeE.
+func ServeE(fn ...any) error {
+	return Default.ServeE(fn...)
+}
+
 // End triggers application termination
 // It terminates the application by sending a SIGINT signal to the default Application instance
 // This is a convenience method equivalent to calling Default.End()
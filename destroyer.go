@@ -0,0 +1,95 @@
+package gone
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// destroyerOf returns the function releasing the resources held by v, if v implements Destroyer or io.Closer.
+func destroyerOf(v any) func() error {
+	switch d := v.(type) {
+	case Destroyer:
+		return d.Destroy
+	case io.Closer:
+		return d.Close
+	}
+	return nil
+}
+
+// trackProvided records v supplied by the provider co, so that v is destroyed together with co.
+// The goner itself and values already recorded, e.g. the value of a constructor, are not recorded again.
+func (s *installer) trackProvided(co *coffin, v any) {
+	if v == nil || destroyerOf(v) == nil || sameValue(v, co.goner) {
+		return
+	}
+	s.providedMutex.Lock()
+	defer s.providedMutex.Unlock()
+	for _, p := range co.provided {
+		if sameValue(p, v) {
+			return
+		}
+	}
+	co.provided = append(co.provided, v)
+}
+
+// sameValue reports whether a and b are the same value, values of uncomparable types are never the same.
+func sameValue(a, b any) bool {
+	return a != nil && b != nil && reflect.TypeOf(a) == reflect.TypeOf(b) && reflect.TypeOf(a).Comparable() && a == b
+}
+
+// destroy releases the resources of the Goners installed by the core, in the reverse order of installation.
+// The values supplied by a provider are destroyed, in reverse order, right before the provider itself.
+// The core of a request scope destroys the instances of request scoped Goners first, in the reverse order of creation.
+// Destroying continues when a Goner fails, and all the errors are returned together.
+func (s *core) destroy() error {
+	var errs []error
+	if s.ownsRequestInstances {
+		created := s.requestInstances.created
+		for i := len(created) - 1; i >= 0; i-- {
+			if err := safeDestroy(created[i]); err != nil {
+				errs = append(errs, ToErrorWithMsg(err, fmt.Sprintf("failed to destroy request scoped %s", GetTypeName(reflect.TypeOf(created[i])))))
+			}
+		}
+		s.requestInstances.created = nil
+	}
+	for i := len(s.installOrder) - 1; i >= 0; i-- {
+		co := s.installOrder[i]
+		for j := len(co.provided) - 1; j >= 0; j-- {
+			if err := safeDestroy(co.provided[j]); err != nil {
+				errs = append(errs, ToErrorWithMsg(err, fmt.Sprintf("failed to destroy %s provided by %s", GetTypeName(reflect.TypeOf(co.provided[j])), co.Name())))
+			}
+		}
+		co.provided = nil
+		if err := safeDestroy(co.goner); err != nil {
+			errs = append(errs, ToErrorWithMsg(err, fmt.Sprintf("failed to destroy %s", co.Name())))
+		}
+	}
+	s.installOrder = nil
+	return NewMultiError(DestroyError, "failed to destroy goners", errs...)
+}
+
+func safeDestroy(v any) error {
+	fn := destroyerOf(v)
+	if fn == nil {
+		return nil
+	}
+	return SafeExecute(fn)
+}
+
+// reset makes the Goners of the core installed again by the next Install, after they are destroyed.
+func (s *core) reset() {
+	for _, co := range s.iKeeper.getAllCoffins() {
+		co.isFill, co.isInit = false, false
+		co.provided = nil
+		co.injectionsMutex.Lock()
+		co.injections = nil
+		co.injectionsMutex.Unlock()
+	}
+	if i, ok := s.iInstaller.(*installer); ok {
+		i.decoratedMutex.Lock()
+		i.decorated = nil
+		i.decoratedMutex.Unlock()
+	}
+	s.installed = false
+}
@@ -0,0 +1,179 @@
+package gone
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type destroyLog struct {
+	names []string
+}
+
+type destroyRepo struct {
+	Flag
+	log *destroyLog
+}
+
+func (r *destroyRepo) Init() {}
+
+func (r *destroyRepo) Destroy() error {
+	r.log.names = append(r.log.names, "repo")
+	return nil
+}
+
+type destroyService struct {
+	Flag
+	repo *destroyRepo `gone:"*"`
+	log  *destroyLog
+	err  error
+}
+
+func (s *destroyService) Init() {}
+
+func (s *destroyService) Close() error {
+	s.log.names = append(s.log.names, "service")
+	return s.err
+}
+
+type destroyConn struct {
+	name string
+	log  *destroyLog
+}
+
+func (c *destroyConn) Close() error {
+	c.log.names = append(c.log.names, c.name)
+	return nil
+}
+
+type destroyConnProvider struct {
+	Flag
+	log *destroyLog
+}
+
+func (p *destroyConnProvider) Init() {}
+
+func (p *destroyConnProvider) Provide(tagConf string) (*destroyConn, error) {
+	return &destroyConn{name: "conn-" + tagConf, log: p.log}, nil
+}
+
+type destroyHandler struct {
+	Flag
+	service *destroyService `gone:"*"`
+	conn1   *destroyConn    `gone:"*,a"`
+	conn2   *destroyConn    `gone:"*,b"`
+	log     *destroyLog
+}
+
+func (h *destroyHandler) Destroy() error {
+	h.log.names = append(h.log.names, "handler")
+	return nil
+}
+
+func TestDestroyer(t *testing.T) {
+	t.Run("reverse install order", func(t *testing.T) {
+		log := &destroyLog{}
+		NewApp().
+			Load(&destroyHandler{log: log}).
+			Load(&destroyConnProvider{log: log}).
+			Load(&destroyService{log: log}).
+			Load(&destroyRepo{log: log}).
+			Run()
+
+		index := make(map[string]int)
+		for i, name := range log.names {
+			index[name] = i
+		}
+		if len(index) != 5 {
+			t.Fatalf("unexpected destroyed goners %v", log.names)
+		}
+		for _, pair := range [][2]string{
+			{"handler", "service"},
+			{"handler", "conn-a"},
+			{"service", "repo"},
+			{"conn-b", "conn-a"},
+		} {
+			if index[pair[0]] > index[pair[1]] {
+				t.Errorf("%s should be destroyed before %s, got %v", pair[0], pair[1], log.names)
+			}
+		}
+	})
+
+	t.Run("continue after errors", func(t *testing.T) {
+		log := &destroyLog{}
+		app := NewApp().
+			Load(&destroyService{log: log, err: errors.New("close failed")}).
+			Load(&destroyRepo{log: log})
+		if err := app.loader.Install(); err != nil {
+			t.Fatal(err)
+		}
+
+		err := app.loader.destroy()
+		if err == nil || !strings.Contains(err.Error(), "close failed") {
+			t.Errorf("unexpected error %v", err)
+		}
+		if got := strings.Join(log.names, ","); got != "service,repo" {
+			t.Errorf("got %s", got)
+		}
+		if err = app.loader.destroy(); err != nil {
+			t.Errorf("goners should be destroyed only once, got %v", err)
+		}
+	})
+
+	t.Run("constructed value", func(t *testing.T) {
+		log := &destroyLog{}
+		NewApp().
+			LoadConstructor(func() *destroyConn {
+				return &destroyConn{name: "constructed", log: log}
+			}).
+			Run(func(c1 *destroyConn, c2 *destroyConn) {})
+
+		if got := strings.Join(log.names, ","); got != "constructed" {
+			t.Errorf("got %s", got)
+		}
+	})
+
+	t.Run("scope", func(t *testing.T) {
+		log := &destroyLog{}
+		app := NewApp().Load(&destroyRepo{log: log})
+		if err := app.loader.Install(); err != nil {
+			t.Fatal(err)
+		}
+
+		scope := app.NewScope().Load(&destroyService{log: log})
+		if err := scope.Install(); err != nil {
+			t.Fatal(err)
+		}
+		if err := scope.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Join(log.names, ","); got != "service" {
+			t.Errorf("got %s", got)
+		}
+	})
+	t.Run("request scoped", func(t *testing.T) {
+		log := &destroyLog{}
+		app := NewApp().
+			Load(&destroyRepo{log: log}, RequestScoped()).
+			Load(&destroyService{log: log}, RequestScoped())
+		if err := app.loader.Install(); err != nil {
+			t.Fatal(err)
+		}
+
+		scope := app.NewRequestScope(context.Background())
+		child := scope.NewScope()
+		if err := child.Run(func(s *destroyService) {}); err != nil {
+			t.Fatal(err)
+		}
+		if err := child.Close(); err != nil || len(log.names) != 0 {
+			t.Fatalf("child scope should not destroy request scoped goners, got %v, %v", err, log.names)
+		}
+		if err := scope.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Join(log.names, ","); got != "service,repo" {
+			t.Errorf("got %s", got)
+		}
+	})
+}
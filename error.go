@@ -2,9 +2,11 @@ package gone
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"net/http"
 	"runtime"
+	"strings"
 )
 
 // Error normal error
@@ -310,6 +312,49 @@ func newIError(err error, code int, skip int) Error {
 	}
 }
 
+// MultiError is an Error which collects several errors, for example the errors of all daemons failed to stop.
+// errors.Is and errors.As match any of the collected errors.
+type MultiError interface {
+	Error
+	Errors() []error
+}
+
+type multiError struct {
+	*defaultErr
+	errs []error
+}
+
+// NewMultiError creates a MultiError with the code and message from the errors, nil errors are ignored.
+// It returns nil if no error is given.
+func NewMultiError(code int, msg string, errs ...error) Error {
+	var list []error
+	for _, err := range errs {
+		if err != nil {
+			list = append(list, err)
+		}
+	}
+	if len(list) == 0 {
+		return nil
+	}
+	return &multiError{
+		defaultErr: &defaultErr{code: code, msg: msg, statusCode: http.StatusInternalServerError, cause: errors.Join(list...)},
+		errs:       list,
+	}
+}
+
+func (e *multiError) Error() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%s: %d error(s) occurred:", e.defaultErr.Error(), len(e.errs)))
+	for i, err := range e.errs {
+		b.WriteString(fmt.Sprintf("\n[%d] %s", i+1, err))
+	}
+	return b.String()
+}
+
+func (e *multiError) Errors() []error {
+	return e.errs
+}
+
 // Error Code：1001~1999 used for gone framework.
 const (
 	GonerNameNotFound   = 1001
@@ -325,4 +370,9 @@ const (
 	StartError          = 1011
 	DbRollForPanicError = 1012
 	PanicError          = 1013
+	StrictModeError     = 1014
+	DestroyError        = 1015
+	ShutdownTimeout     = 1016
+	StopError           = 1017
+	ReloadError         = 1018
 )
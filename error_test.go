@@ -428,3 +428,28 @@ func TestWrapError(t *testing.T) {
 		}
 	})
 }
+
+func TestNewMultiError(t *testing.T) {
+	t.Run("no error", func(t *testing.T) {
+		if err := NewMultiError(StopError, "failed", nil, nil); err != nil {
+			t.Errorf("expected nil, got %v", err)
+		}
+	})
+
+	t.Run("collect errors", func(t *testing.T) {
+		e1 := errors.New("first")
+		e2 := NewInnerError("second", NotSupport)
+		err := NewMultiError(StopError, "failed", e1, nil, e2)
+
+		var m MultiError
+		if !errors.As(err, &m) || m.Code() != StopError || m.Msg() != "failed" || len(m.Errors()) != 2 {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if !errors.Is(err, e1) || !errors.Is(err, e2) {
+			t.Error("collected errors should be matched by errors.Is")
+		}
+		if msg := err.Error(); !strings.Contains(msg, "2 error(s) occurred") || !strings.Contains(msg, "[1] first") {
+			t.Errorf("unexpected message %s", msg)
+		}
+	})
+}
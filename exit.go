@@ -0,0 +1,101 @@
+package gone
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Exit codes returned by ExitCode, for the error returned by Application.RunE and Application.ServeE.
+const (
+	ExitOK              = 0
+	ExitFailure         = 1 // a run function returned an error, or an unknown error occurred
+	ExitInstallFailure  = 2 // Goners failed to be loaded, filled or initialized
+	ExitStartFailure    = 3 // a BeforeStart or AfterStart hook, or a daemon failed to start
+	ExitStopFailure     = 4 // a BeforeStop or AfterStop hook, a daemon or a Destroyer failed to stop
+	ExitShutdownTimeout = 5 // a daemon did not stop before the shutdown timeout
+	ExitForced          = 6 // a stop signal was received again while the application was stopping
+)
+
+// phaseError records the exit code of the phase of the application in which the error occurred.
+type phaseError struct {
+	err      Error
+	exitCode int
+}
+
+func newPhaseError(err error, exitCode int) error {
+	if err == nil {
+		return nil
+	}
+	return &phaseError{err: ToError(err), exitCode: exitCode}
+}
+
+func (e *phaseError) Error() string {
+	return e.err.Error()
+}
+func (e *phaseError) Msg() string {
+	return e.err.Msg()
+}
+func (e *phaseError) SetMsg(msg string) {
+	e.err.SetMsg(msg)
+}
+func (e *phaseError) Code() int {
+	return e.err.Code()
+}
+func (e *phaseError) GetStatusCode() int {
+	return e.err.GetStatusCode()
+}
+func (e *phaseError) Unwrap() error {
+	return e.err
+}
+
+// ExitCode maps the error returned by Application.RunE or Application.ServeE to a process exit code,
+// so that a command line program can exit with it:
+//
+//	os.Exit(gone.ExitCode(app.ServeE()))
+//
+// It returns ExitOK for nil, ExitShutdownTimeout if any daemon hung, the exit code of the phase in which the error
+// occurred, and ExitFailure for errors returned by run functions or not returned by the Application.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	if hasErrorCode(err, ShutdownTimeout) {
+		return ExitShutdownTimeout
+	}
+	var p *phaseError
+	if errors.As(err, &p) {
+		return p.exitCode
+	}
+	return ExitFailure
+}
+
+// hasErrorCode reports whether any error in the tree of err is an Error with the code.
+func hasErrorCode(err error, code int) bool {
+	if err == nil {
+		return false
+	}
+	if e, ok := err.(Error); ok && e.Code() == code {
+		return true
+	}
+	switch x := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, e := range x.Unwrap() {
+			if hasErrorCode(e, code) {
+				return true
+			}
+		}
+	case interface{ Unwrap() error }:
+		return hasErrorCode(x.Unwrap(), code)
+	}
+	return false
+}
+
+// returnedError returns the error returned by a run function, which is the last result if its type is error.
+func returnedError(fn any, results []any) error {
+	ft := reflect.TypeOf(fn)
+	if ft.NumOut() == 0 || ft.Out(ft.NumOut()-1) != errType {
+		return nil
+	}
+	err, _ := results[len(results)-1].(error)
+	return err
+}
@@ -0,0 +1,133 @@
+package gone
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type exitTestDaemon struct {
+	Flag
+	startErr error
+	stopErr  error
+	stopped  bool
+}
+
+func (d *exitTestDaemon) Start() error {
+	return d.startErr
+}
+
+func (d *exitTestDaemon) Stop() error {
+	d.stopped = true
+	return d.stopErr
+}
+
+type exitTestNeedDep struct {
+	Flag
+	dep *exitTestDaemon `gone:"*"`
+}
+
+func TestApplication_RunE(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		err := NewApp().RunE(func() error {
+			return nil
+		})
+		if err != nil || ExitCode(err) != ExitOK {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+
+	t.Run("install error", func(t *testing.T) {
+		err := NewApp().Load(&exitTestNeedDep{}).RunE()
+		if err == nil || ExitCode(err) != ExitInstallFailure {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+
+	t.Run("start error", func(t *testing.T) {
+		started := &exitTestDaemon{}
+		failed := &exitTestDaemon{startErr: errors.New("start failed")}
+		err := NewApp().
+			Load(started, Order(1)).
+			Load(failed, Order(2)).
+			RunE()
+		if err == nil || !strings.Contains(err.Error(), "start failed") || ExitCode(err) != ExitStartFailure {
+			t.Errorf("unexpected error %v", err)
+		}
+		if !started.stopped || failed.stopped {
+			t.Error("only the started daemons should be stopped")
+		}
+	})
+
+	t.Run("run function error", func(t *testing.T) {
+		d := &exitTestDaemon{}
+		executed := false
+		err := NewApp().Load(d).RunE(func() (int, error) {
+			return 0, errors.New("run failed")
+		}, func() {
+			executed = true
+		})
+		if err == nil || !strings.Contains(err.Error(), "run failed") || ExitCode(err) != ExitFailure {
+			t.Errorf("unexpected error %v", err)
+		}
+		if executed {
+			t.Error("functions after the failed one should not be executed")
+		}
+		if !d.stopped {
+			t.Error("the application should be stopped")
+		}
+	})
+
+	t.Run("run function and stop error", func(t *testing.T) {
+		err := NewApp().
+			Load(&exitTestDaemon{stopErr: errors.New("stop failed")}).
+			RunE(func() error {
+				return errors.New("run failed")
+			})
+		if err == nil || !strings.Contains(err.Error(), "run failed") || !strings.Contains(err.Error(), "stop failed") {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+
+	t.Run("stop error", func(t *testing.T) {
+		err := NewApp().Load(&exitTestDaemon{stopErr: errors.New("stop failed")}).RunE()
+		if err == nil || ExitCode(err) != ExitStopFailure {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+
+	t.Run("Run ignores returned error", func(t *testing.T) {
+		executed := false
+		NewApp().Run(func() error {
+			return errors.New("run failed")
+		}, func() {
+			executed = true
+		})
+		if !executed {
+			t.Error("functions after the failed one should be executed")
+		}
+	})
+}
+
+func TestExitCode(t *testing.T) {
+	timeout := NewMultiError(StopError, "failed to stop",
+		errors.New("other"), NewInnerError("daemon hung", ShutdownTimeout),
+	)
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"unknown error", errors.New("unknown"), ExitFailure},
+		{"phase error", newPhaseError(errors.New("install"), ExitInstallFailure), ExitInstallFailure},
+		{"shutdown timeout", newPhaseError(timeout, ExitStopFailure), ExitShutdownTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
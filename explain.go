@@ -0,0 +1,237 @@
+package gone
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Rules by which the Goners injected into a field are selected, see Explanation.Rule.
+const (
+	RuleByName        = "name"           // the gone tag names the Goner
+	RuleOnlyCandidate = "only candidate" // it is the only Goner which can be injected
+	RuleDefault       = "default"        // it is the default Goner of the field type, see IsDefault
+	RuleFirstByOrder  = "first by order" // there are multiple candidates without a default, the first by Order wins
+	RuleParent        = "parent"         // it is found in the parent container, see Scope
+	RuleAll           = "all"            // all candidates are injected into a slice or a map
+	RuleNotFound      = "not found"      // no Goner can be injected
+)
+
+// Explanation tells why a field of a Goner is injected with the Goners it is injected with, see Application.Explain.
+type Explanation struct {
+	Goner string
+	Field string
+	Type  string
+
+	// Pattern is the name or name pattern in the gone tag of the field, "*" if the tag names no Goner.
+	Pattern string
+
+	// Candidates are all the Goners considered, in loading order, with the reasons of rejected ones. In a Scope,
+	// the Goners of the Scope come first, followed by the ones of the parent which are not shadowed by name.
+	Candidates []Candidate
+
+	// Selected are the Goners injected into the field, which are more than one for slices and maps.
+	Selected []string
+
+	// Rule is the rule by which Selected are picked, like RuleByName or RuleDefault.
+	Rule string
+}
+
+// Candidate is a Goner considered for injecting a field.
+type Candidate struct {
+	Goner string
+	Order int
+
+	// Rejected tells why the Goner cannot be injected into the field, it is empty for accepted ones.
+	Rejected string
+}
+
+func (e *Explanation) String() string {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "field %q (%s) of %s, pattern %q:", e.Field, e.Type, e.Goner, e.Pattern)
+	for _, c := range e.Candidates {
+		if c.Rejected != "" {
+			_, _ = fmt.Fprintf(&b, "\n\t- %s (order=%d): rejected, %s", c.Goner, c.Order, c.Rejected)
+		} else {
+			_, _ = fmt.Fprintf(&b, "\n\t+ %s (order=%d): accepted", c.Goner, c.Order)
+		}
+	}
+	if len(e.Selected) == 0 {
+		_, _ = fmt.Fprintf(&b, "\nselected nothing")
+	} else {
+		_, _ = fmt.Fprintf(&b, "\nselected %s by rule %q", strings.Join(e.Selected, ", "), e.Rule)
+	}
+	return b.String()
+}
+
+// Explain tells why the field of the loaded goner is injected with the Goners it is injected with: the Goners
+// considered, why each of them is rejected, and which rule picks the injected ones. goner is either a loaded Goner
+// or the name of one. It can be called before the Application is installed, to find out what will be injected.
+//
+// Example usage:
+//
+//	explanation, err := app.Explain(&UserService{}, "repo")
+//	if err == nil {
+//	    fmt.Println(explanation)
+//	}
+func (s *Application) Explain(goner any, fieldName string) (*Explanation, error) {
+	return s.loader.Explain(goner, fieldName)
+}
+
+// Explain tells why the field of the goner loaded into the core is injected, see Application.Explain.
+func (s *core) Explain(goner any, fieldName string) (*Explanation, error) {
+	var co *coffin
+	if name, ok := goner.(string); ok {
+		co = s.iKeeper.getByName(name)
+	} else {
+		for _, c := range s.iKeeper.getAllCoffins() {
+			if sameValue(c.goner, goner) {
+				co = c
+				break
+			}
+		}
+	}
+	if co == nil {
+		return nil, NewInnerErrorWithParams(GonerNameNotFound, "cannot explain: %v is not loaded", goner)
+	}
+
+	t := reflect.TypeOf(co.goner)
+	if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return nil, NewInnerErrorWithParams(GonerTypeNotMatch, "cannot explain: %s is not a pointer to struct", co.Name())
+	}
+	field, ok := t.Elem().FieldByName(fieldName)
+	if !ok {
+		return nil, NewInnerErrorWithParams(NotSupport, "cannot explain: %s has no field %q", co.Name(), fieldName)
+	}
+	if target, ok := lazyTargetField(field); ok {
+		field = target
+	} else if target, ok := optionalTargetField(field); ok {
+		field = target
+	}
+	if _, ok = field.Tag.Lookup(goneTag); !ok {
+		return nil, NewInnerErrorWithParams(NotSupport, "cannot explain: field %q of %s is not injected by gone", fieldName, co.Name())
+	}
+	return s.iDependenceAnalyzer.explain(field, co.Name()), nil
+}
+
+func (s *dependenceAnalyzer) explain(field reflect.StructField, coName string) *Explanation {
+	gonerName, _ := ParseGoneTag(field.Tag.Get(goneTag))
+	if gonerName == "" {
+		gonerName = "*"
+	}
+	e := &Explanation{
+		Goner:   coName,
+		Field:   field.Name,
+		Type:    GetTypeName(field.Type),
+		Pattern: gonerName,
+		Rule:    RuleNotFound,
+	}
+
+	var selected []*coffin
+	multiple := false
+	var asSlice, byName bool
+	_ = s.analyzeFieldDependencies(field, coName, func() {
+		multiple = true
+	}, func(slice, name bool, extend string, coffins ...*coffin) error {
+		asSlice, byName, selected = slice, name, coffins
+		return nil
+	})
+	for _, co := range selected {
+		e.Selected = append(e.Selected, co.Name())
+	}
+
+	t := field.Type
+	if asSlice {
+		t = t.Elem()
+	}
+	isPattern := strings.ContainsAny(gonerName, "*?")
+	e.Candidates = s.explainCandidates(t, gonerName, isPattern, asSlice)
+
+	switch {
+	case len(selected) == 0:
+	case byName:
+		e.Rule = RuleByName
+	case asSlice:
+		e.Rule = RuleAll
+	case !s.isLocal(selected[0]):
+		e.Rule = RuleParent
+	case multiple:
+		e.Rule = RuleFirstByOrder
+	case selected[0].isDefault(t) && acceptedCount(e.Candidates) > 1:
+		e.Rule = RuleDefault
+	default:
+		e.Rule = RuleOnlyCandidate
+	}
+	return e
+}
+
+// explainCandidates checks every Goner visible to the keeper in the same way as the keeper, see
+// keeper.getByTypeAndPattern and keeper.selectOneCoffin, and tells why it cannot be injected as a value of type t.
+func (s *dependenceAnalyzer) explainCandidates(t reflect.Type, gonerName string, isPattern, asSlice bool) (candidates []Candidate) {
+	coffins := s.iKeeper.getVisibleCoffins()
+	hasOthers, hasLocal := false, false
+	for _, co := range coffins {
+		if isPattern && !co.onlyForName && !co.fallback && co.CoundProvide(t, false) == nil && isMatch(co.name, gonerName) {
+			hasOthers = true
+			hasLocal = hasLocal || s.isLocal(co)
+		}
+	}
+
+	for _, co := range coffins {
+		c := Candidate{Goner: co.Name(), Order: co.order}
+		if !isPattern {
+			if co.name != gonerName {
+				c.Rejected = fmt.Sprintf("its name is not %q", gonerName)
+			} else if err := co.CoundProvide(t, true); err != nil {
+				c.Rejected = errorMsg(err)
+			}
+		} else if co.onlyForName {
+			c.Rejected = "it is loaded with OnlyForName, and can only be injected by name"
+		} else if err := co.CoundProvide(t, false); err != nil {
+			c.Rejected = errorMsg(err)
+		} else if !isMatch(co.name, gonerName) {
+			c.Rejected = fmt.Sprintf("its name %q does not match pattern %q", co.name, gonerName)
+		} else if co.fallback && hasOthers {
+			c.Rejected = "it is a fallback, and there are other candidates"
+		} else if !asSlice && hasLocal && !s.isLocal(co) {
+			c.Rejected = "it is in the parent, and there are candidates in the scope"
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates
+}
+
+// explainRejected describes the Goners which could provide a value of type t but are rejected, for the error of
+// a field which cannot be injected.
+func (s *dependenceAnalyzer) explainRejected(t reflect.Type, gonerName string) string {
+	coffins := s.iKeeper.getVisibleCoffins()
+	var lines []string
+	for i, c := range s.explainCandidates(t, gonerName, strings.ContainsAny(gonerName, "*?"), false) {
+		if c.Rejected == "" || coffins[i].CoundProvide(t, true) != nil {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("\t- %s: %s", c.Goner, c.Rejected))
+	}
+	if len(lines) == 0 {
+		return fmt.Sprintf("no loaded goner can provide %q", GetTypeName(t))
+	}
+	return "rejected candidates:\n" + strings.Join(lines, "\n")
+}
+
+func (s *dependenceAnalyzer) isLocal(co *coffin) bool {
+	for _, c := range s.iKeeper.getAllCoffins() {
+		if c == co {
+			return true
+		}
+	}
+	return false
+}
+
+func acceptedCount(candidates []Candidate) (n int) {
+	for _, c := range candidates {
+		if c.Rejected == "" {
+			n++
+		}
+	}
+	return n
+}
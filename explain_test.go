@@ -0,0 +1,204 @@
+package gone
+
+import (
+	"strings"
+	"testing"
+)
+
+type explainStore interface {
+	Store() string
+}
+
+type explainMysql struct {
+	Flag
+}
+
+func (s *explainMysql) Store() string {
+	return "mysql"
+}
+
+type explainRedis struct {
+	Flag
+}
+
+func (s *explainRedis) Store() string {
+	return "redis"
+}
+
+type explainUser struct {
+	Flag
+	any     explainStore   `gone:"*"`
+	named   explainStore   `gone:"redis"`
+	pattern explainStore   `gone:"my*"`
+	all     []explainStore `gone:"*"`
+	plain   explainStore
+}
+
+func findCandidate(e *Explanation, goner string) *Candidate {
+	for i := range e.Candidates {
+		if e.Candidates[i].Goner == goner {
+			return &e.Candidates[i]
+		}
+	}
+	return nil
+}
+
+func TestApplication_Explain(t *testing.T) {
+	user := &explainUser{}
+	newApp := func(options ...Option) *Application {
+		return NewApp().
+			Load(&explainMysql{}, append([]Option{Name("mysql"), Order(2)}, options...)...).
+			Load(&explainRedis{}, Name("redis"), Order(1)).
+			Load(&explainMysql{}, Name("mysql-replica"), OnlyForName()).
+			Load(user)
+	}
+
+	t.Run("first by order", func(t *testing.T) {
+		e, err := newApp().Explain(user, "any")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Rule != RuleFirstByOrder || strings.Join(e.Selected, ",") != "Goner(name=redis)" {
+			t.Errorf("unexpected explanation %s", e)
+		}
+		if c := findCandidate(e, "Goner(name=mysql)"); c == nil || c.Rejected != "" || c.Order != 2 {
+			t.Errorf("mysql should be an accepted candidate, got %+v", c)
+		}
+		if c := findCandidate(e, "Goner(name=mysql-replica)"); c == nil || !strings.Contains(c.Rejected, "OnlyForName") {
+			t.Errorf("mysql-replica should be rejected for OnlyForName, got %+v", c)
+		}
+		if c := findCandidate(e, "*gone.explainUser"); c == nil || !strings.Contains(c.Rejected, "cannot provide") {
+			t.Errorf("user should be rejected for its type, got %+v", c)
+		}
+	})
+
+	t.Run("default", func(t *testing.T) {
+		e, err := newApp(IsDefault(new(explainStore))).Explain(user, "any")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Rule != RuleDefault || strings.Join(e.Selected, ",") != "Goner(name=mysql)" {
+			t.Errorf("unexpected explanation %s", e)
+		}
+	})
+
+	t.Run("by name", func(t *testing.T) {
+		e, err := newApp().Explain(user, "named")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Rule != RuleByName || e.Pattern != "redis" || strings.Join(e.Selected, ",") != "Goner(name=redis)" {
+			t.Errorf("unexpected explanation %s", e)
+		}
+		if c := findCandidate(e, "Goner(name=mysql)"); c == nil || c.Rejected != `its name is not "redis"` {
+			t.Errorf("mysql should be rejected for its name, got %+v", c)
+		}
+	})
+
+	t.Run("pattern", func(t *testing.T) {
+		e, err := newApp().Explain(user, "pattern")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Rule != RuleOnlyCandidate || strings.Join(e.Selected, ",") != "Goner(name=mysql)" {
+			t.Errorf("unexpected explanation %s", e)
+		}
+		if c := findCandidate(e, "Goner(name=redis)"); c == nil || c.Rejected != `its name "redis" does not match pattern "my*"` {
+			t.Errorf("redis should be rejected for its name, got %+v", c)
+		}
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		e, err := newApp().Explain(user, "all")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Rule != RuleAll || strings.Join(e.Selected, ",") != "Goner(name=redis),Goner(name=mysql)" {
+			t.Errorf("unexpected explanation %s", e)
+		}
+		if s := e.String(); !strings.Contains(s, `selected Goner(name=redis), Goner(name=mysql) by rule "all"`) {
+			t.Errorf("unexpected string %s", s)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		app := newApp()
+		if _, err := app.Explain(&explainUser{}, "any"); err == nil {
+			t.Error("goner not loaded should fail")
+		}
+		if _, err := app.Explain(user, "missing"); err == nil {
+			t.Error("missing field should fail")
+		}
+		if _, err := app.Explain(user, "plain"); err == nil {
+			t.Error("field without gone tag should fail")
+		}
+		if _, err := app.Explain("unknown", "any"); err == nil {
+			t.Error("unknown name should fail")
+		}
+		if _, err := app.Explain("redis", "Flag"); err == nil || !strings.Contains(err.Error(), "is not injected by gone") {
+			t.Errorf("goner found by name should be checked, got %v", err)
+		}
+	})
+}
+
+func TestExplain_installError(t *testing.T) {
+	type consumer struct {
+		Flag
+		store explainStore `gone:"postgres*"`
+	}
+	err := NewApp().
+		Load(&explainMysql{}, Name("mysql")).
+		Load(&explainRedis{}, Name("redis"), OnlyForName()).
+		Load(&consumer{}).
+		loader.Install()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	msg := err.Error()
+	for _, s := range []string{
+		"rejected candidates:",
+		`Goner(name=mysql): its name "mysql" does not match pattern "postgres*"`,
+		"Goner(name=redis): it is loaded with OnlyForName",
+	} {
+		if !strings.Contains(msg, s) {
+			t.Errorf("error should contain %q, got %s", s, msg)
+		}
+	}
+}
+
+func TestScope_Explain(t *testing.T) {
+	user := &explainUser{}
+	app := NewApp().
+		Load(&explainMysql{}, Name("mysql")).
+		Load(&explainRedis{}, Name("redis"))
+
+	t.Run("parent", func(t *testing.T) {
+		e, err := app.NewScope().Load(user).Explain(user, "pattern")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Rule != RuleParent || strings.Join(e.Selected, ",") != "Goner(name=mysql)" {
+			t.Errorf("unexpected explanation %s", e)
+		}
+		if c := findCandidate(e, "Goner(name=mysql)"); c == nil || c.Rejected != "" {
+			t.Errorf("mysql of the parent should be an accepted candidate, got %+v", c)
+		}
+	})
+
+	t.Run("shadowed", func(t *testing.T) {
+		e, err := app.NewScope().
+			Load(&explainMysql{}, Name("mysql-local")).
+			Load(&explainRedis{}, Name("redis")).
+			Load(user).
+			Explain(user, "any")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Count(e.String(), "Goner(name=redis)") != 1 {
+			t.Errorf("redis of the parent should be shadowed, got %s", e)
+		}
+		if c := findCandidate(e, "Goner(name=mysql)"); c == nil || c.Rejected != "it is in the parent, and there are candidates in the scope" {
+			t.Errorf("mysql of the parent should be rejected, got %+v", c)
+		}
+	})
+}
@@ -0,0 +1,231 @@
+package gone
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Graph is the dependency graph of the Goners loaded into an Application, exported by Application.Graph for
+// architecture documents and reviews. Goners loaded by gone itself are omitted.
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// GraphNode is a Goner in the Graph.
+type GraphNode struct {
+	// ID identifies the node in the Graph, it is stable for the same loading order.
+	ID string `json:"id"`
+
+	// Name is the name of the Goner, it is empty for Goners loaded without a name.
+	Name string `json:"name,omitempty"`
+
+	// Type is the type of the Goner, or of the value created by it for constructors.
+	Type string `json:"type"`
+
+	// Options are the loading options of the Goner, like "prototype", "lazy-fill" or "provider=*sql.DB".
+	Options []string `json:"options,omitempty"`
+}
+
+// Label returns the name of the node, or its type if it has no name.
+func (n GraphNode) Label() string {
+	if n.Name != "" {
+		return n.Name
+	}
+	return n.Type
+}
+
+// GraphEdge means that the Goner From depends on the Goner To.
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+
+	// Field is the field of From injected with To, or the parameter of the constructor or decorator of From,
+	// like "parameter 1".
+	Field string `json:"field"`
+
+	// Action is the action of From which needs To: "fill" for fields, "init" for parameters of constructors and
+	// decorators, which are called when From is initialized.
+	Action string `json:"action"`
+}
+
+// Graph returns the dependency graph of the loaded Goners. Dependencies which cannot be resolved are omitted,
+// they are reported by installing the Application. Lazy handles are not dependencies, see Lazy.
+func (s *Application) Graph() *Graph {
+	return s.loader.Graph()
+}
+
+// Graph returns the dependency graph of the Goners loaded into the core, see Application.Graph.
+func (s *core) Graph() *Graph {
+	return s.iDependenceAnalyzer.getGraph()
+}
+
+// getGraph builds the Graph with the dependencies collected in the same way as installing, see getGonerDeps.
+func (s *dependenceAnalyzer) getGraph() *Graph {
+	g := &Graph{}
+	ids := make(map[*coffin]string)
+	var coffins []*coffin
+	for i, co := range s.iKeeper.getAllCoffins() {
+		if co.builtin {
+			continue
+		}
+		id := fmt.Sprintf("n%d", i)
+		ids[co] = id
+		coffins = append(coffins, co)
+		g.Nodes = append(g.Nodes, GraphNode{
+			ID:      id,
+			Name:    co.name,
+			Type:    coffinTypeName(co),
+			Options: coffinOptions(co),
+		})
+	}
+
+	added := make(map[GraphEdge]bool)
+	for _, co := range coffins {
+		_, _, _ = s.getGonerDeps(co, func(action actionType, field string, depCoffins []*coffin) {
+			for _, depCo := range depCoffins {
+				to, ok := ids[depCo]
+				if !ok || depCo == co {
+					continue
+				}
+				e := GraphEdge{From: ids[co], To: to, Field: field, Action: "fill"}
+				if action == initAction {
+					e.Action = "init"
+				}
+				if !added[e] {
+					added[e] = true
+					g.Edges = append(g.Edges, e)
+				}
+			}
+		})
+	}
+	return g
+}
+
+func coffinTypeName(co *coffin) string {
+	if co.constructor != nil && co.constructor.t != nil {
+		return GetTypeName(co.constructor.t)
+	}
+	return GetTypeName(reflect.TypeOf(co.goner))
+}
+
+// coffinOptions describes the options co is loaded with.
+func coffinOptions(co *coffin) (options []string) {
+	add := func(enabled bool, option string) {
+		if enabled {
+			options = append(options, option)
+		}
+	}
+	add(co.prototype, "prototype")
+	add(co.requestScoped, "request-scoped")
+	add(co.lazyFill, "lazy-fill")
+	add(co.onlyForName, "only-for-name")
+	add(co.forceReplace, "force-replace")
+	add(co.fallback, "fallback")
+	add(co.order != 0, fmt.Sprintf("order=%d", co.order))
+	add(co.constructor != nil, "constructor")
+	add(co.provider != nil, fmt.Sprintf("provider=%s", providedTypeName(co)))
+	add(co.decorator != nil && co.decorator.t != nil, fmt.Sprintf("decorator=%s", decoratedTypeName(co)))
+	add(co.initPolicy.timeout > 0, fmt.Sprintf("init-timeout=%s", co.initPolicy.timeout))
+	add(co.initPolicy.retries > 0, fmt.Sprintf("init-retry=%d", co.initPolicy.retries))
+
+	var defaults []string
+	for t, ok := range co.defaultTypeMap {
+		if ok {
+			defaults = append(defaults, GetTypeName(t))
+		}
+	}
+	sort.Strings(defaults)
+	add(len(defaults) > 0, fmt.Sprintf("default=%s", strings.Join(defaults, ",")))
+	return options
+}
+
+func providedTypeName(co *coffin) string {
+	if co.provider == nil || co.provider.t == nil {
+		return ""
+	}
+	return GetTypeName(co.provider.t)
+}
+
+func decoratedTypeName(co *coffin) string {
+	if co.decorator == nil || co.decorator.t == nil {
+		return ""
+	}
+	return GetTypeName(co.decorator.t)
+}
+
+// DOT exports the Graph in the DOT language of Graphviz.
+//
+// Example usage:
+//
+//	os.WriteFile("deps.dot", []byte(app.Graph().DOT()), 0644) // then: dot -Tsvg deps.dot -o deps.svg
+func (g *Graph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph gone {\n\trankdir=LR;\n\tnode [shape=box];\n")
+	for _, n := range g.Nodes {
+		label := n.Label()
+		if n.Name != "" {
+			label += "\n" + n.Type
+		}
+		if len(n.Options) > 0 {
+			label += "\n[" + strings.Join(n.Options, " ") + "]"
+		}
+		_, _ = fmt.Fprintf(&b, "\t%s [label=%s];\n", n.ID, dotQuote(label))
+	}
+	for _, e := range g.Edges {
+		style := ""
+		if e.Action == "init" {
+			style = ", style=dashed"
+		}
+		_, _ = fmt.Fprintf(&b, "\t%s -> %s [label=%s%s];\n", e.From, e.To, dotQuote(e.Field), style)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+// Mermaid exports the Graph as a Mermaid flowchart, which can be embedded in Markdown documents.
+func (g *Graph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, n := range g.Nodes {
+		label := n.Label()
+		if n.Name != "" {
+			label += "<br/>" + n.Type
+		}
+		if len(n.Options) > 0 {
+			label += "<br/>[" + strings.Join(n.Options, " ") + "]"
+		}
+		_, _ = fmt.Fprintf(&b, "\t%s[\"%s\"]\n", n.ID, mermaidEscape(label))
+	}
+	for _, e := range g.Edges {
+		arrow := "-->"
+		if e.Action == "init" {
+			arrow = "-.->"
+		}
+		_, _ = fmt.Fprintf(&b, "\t%s %s|\"%s\"| %s\n", e.From, arrow, mermaidEscape(e.Field), e.To)
+	}
+	return b.String()
+}
+
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<br/>", "<br/>", "<", "#lt;", ">", "#gt;").Replace(s)
+}
+
+// JSON exports the Graph as indented JSON.
+func (g *Graph) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return nil, ToError(err)
+	}
+	return data, nil
+}
@@ -0,0 +1,115 @@
+package gone
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type graphDb struct {
+	Flag
+}
+
+type graphRepo struct {
+	Flag
+	db *graphDb `gone:"db"`
+}
+
+type graphService struct {
+	repo *graphRepo
+}
+
+type graphHandler struct {
+	Flag
+	service *graphService  `gone:"*"`
+	repos   []*graphRepo   `gone:"*"`
+	lazyDb  Lazy[*graphDb] `gone:"db"`
+}
+
+const graphPkg = "github.com/gone-io/gone/v2"
+
+func buildGraph(t *testing.T) (*Graph, map[string]GraphNode) {
+	t.Helper()
+	app := NewApp().
+		Load(&graphDb{}, Name("db")).
+		Load(&graphRepo{}, Name("repo"), LazyFill()).
+		LoadConstructor(func(repo *graphRepo) *graphService {
+			return &graphService{repo: repo}
+		}).
+		Load(&graphHandler{})
+
+	g := app.Graph()
+	nodes := make(map[string]GraphNode)
+	for _, n := range g.Nodes {
+		nodes[n.Label()] = n
+	}
+	return g, nodes
+}
+
+func TestApplication_Graph(t *testing.T) {
+	g, nodes := buildGraph(t)
+	if len(g.Nodes) != 4 {
+		t.Fatalf("builtin goners should be omitted, got %v", g.Nodes)
+	}
+
+	db, repo := nodes["db"], nodes["repo"]
+	service, handler := nodes["*"+graphPkg+".graphService"], nodes["*"+graphPkg+".graphHandler"]
+	if db.Type != "*"+graphPkg+".graphDb" || service.ID == "" || handler.ID == "" {
+		t.Fatalf("unexpected nodes %v", g.Nodes)
+	}
+	if strings.Join(repo.Options, " ") != "lazy-fill" {
+		t.Errorf("unexpected options %v", repo.Options)
+	}
+	if strings.Join(service.Options, " ") != "constructor" {
+		t.Errorf("unexpected options %v", service.Options)
+	}
+
+	want := []GraphEdge{
+		{From: repo.ID, To: db.ID, Field: "db", Action: "fill"},
+		{From: service.ID, To: repo.ID, Field: "parameter 1", Action: "init"},
+		{From: handler.ID, To: service.ID, Field: "service", Action: "fill"},
+		{From: handler.ID, To: repo.ID, Field: "repos", Action: "fill"},
+	}
+	if len(g.Edges) != len(want) {
+		t.Fatalf("unexpected edges %v", g.Edges)
+	}
+	for i, e := range want {
+		if g.Edges[i] != e {
+			t.Errorf("edge %d: got %v, want %v", i, g.Edges[i], e)
+		}
+	}
+}
+
+func TestGraph_export(t *testing.T) {
+	g, nodes := buildGraph(t)
+	db, repo := nodes["db"], nodes["repo"]
+
+	dot := g.DOT()
+	for _, s := range []string{"digraph gone {", db.ID + ` [label="db\n*` + graphPkg + `.graphDb"];`, repo.ID + " -> " + db.ID + ` [label="db"];`, `[label="parameter 1", style=dashed]`} {
+		if !strings.Contains(dot, s) {
+			t.Errorf("DOT should contain %q, got:\n%s", s, dot)
+		}
+	}
+
+	mermaid := g.Mermaid()
+	for _, s := range []string{"flowchart LR", db.ID + `["db<br/>*` + graphPkg + `.graphDb"]`, repo.ID + ` -->|"db"| ` + db.ID, `-.->|"parameter 1"|`} {
+		if !strings.Contains(mermaid, s) {
+			t.Errorf("Mermaid should contain %q, got:\n%s", s, mermaid)
+		}
+	}
+
+	data, err := g.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Graph
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Nodes) != len(g.Nodes) || len(decoded.Edges) != len(g.Edges) || decoded.Edges[0] != g.Edges[0] {
+		t.Errorf("unexpected JSON %s", data)
+	}
+	if !strings.Contains(string(data), `"options": [`) || !strings.Contains(string(data), `"action": "fill"`) {
+		t.Errorf("unexpected JSON %s", data)
+	}
+}
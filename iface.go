@@ -34,6 +34,8 @@ func (d dependency) String() string {
 	var name string
 	if d.coffin.name != "" {
 		name = fmt.Sprintf("%q", d.coffin.name)
+	} else if d.coffin.constructor != nil {
+		name = fmt.Sprintf("%q", d.coffin.constructor.funcName())
 	} else {
 		name = fmt.Sprintf("%q", GetTypeName(reflect.TypeOf(d.coffin.goner)))
 	}
@@ -89,9 +91,12 @@ type FuncInjectHook func(pt reflect.Type, i int, injected bool) any
 type iKeeper interface {
 	load(goner Goner, options ...Option) error
 	getAllCoffins() []*coffin
+	getVisibleCoffins() []*coffin
 	getByTypeAndPattern(t reflect.Type, pattern string) []*coffin
 	selectOneCoffin(t reflect.Type, pattern string, warn func()) (depCo *coffin)
 	getByName(name string) *coffin
+	getDecorators(t reflect.Type) []*coffin
+	remove(co *coffin)
 }
 
 type iDependenceAnalyzer interface {
@@ -101,11 +106,23 @@ type iDependenceAnalyzer interface {
 	) error
 
 	checkCircularDepsAndGetBestInitOrder() (circularDeps []dependency, initOrder []dependency, err error)
+	checkStrict() error
+
+	// getInstallGraph returns the dependencies found by the last checkCircularDepsAndGetBestInitOrder,
+	// after the edges of broken interface cycles are removed.
+	getInstallGraph() map[dependency][]dependency
+
+	// getGraph returns the dependency graph of the loaded goners with the injected fields, see Application.Graph.
+	getGraph() *Graph
+
+	// explain tells why field is injected with the goners it is injected with, see Application.Explain.
+	explain(field reflect.StructField, coName string) *Explanation
 }
 
 type iInstaller interface {
 	safeFillOne(c *coffin) error
 	safeInitOne(c *coffin) error
+	provide(co *coffin, byName bool, extend string, t reflect.Type) (any, error)
 
 	analyzerFieldDependencies(
 		field reflect.StructField, coName string,
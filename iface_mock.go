@@ -39,6 +39,20 @@ func (m *MockiKeeper) EXPECT() *MockiKeeperMockRecorder {
 	return m.recorder
 }
 
+// getDecorators mocks base method.
+func (m *MockiKeeper) getDecorators(t reflect.Type) []*coffin {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getDecorators", t)
+	ret0, _ := ret[0].([]*coffin)
+	return ret0
+}
+
+// getDecorators indicates an expected call of getDecorators.
+func (mr *MockiKeeperMockRecorder) getDecorators(t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getDecorators", reflect.TypeOf((*MockiKeeper)(nil).getDecorators), t)
+}
+
 // getAllCoffins mocks base method.
 func (m *MockiKeeper) getAllCoffins() []*coffin {
 	m.ctrl.T.Helper()
@@ -53,6 +67,20 @@ func (mr *MockiKeeperMockRecorder) getAllCoffins() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getAllCoffins", reflect.TypeOf((*MockiKeeper)(nil).getAllCoffins))
 }
 
+// getVisibleCoffins mocks base method.
+func (m *MockiKeeper) getVisibleCoffins() []*coffin {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getVisibleCoffins")
+	ret0, _ := ret[0].([]*coffin)
+	return ret0
+}
+
+// getVisibleCoffins indicates an expected call of getVisibleCoffins.
+func (mr *MockiKeeperMockRecorder) getVisibleCoffins() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getVisibleCoffins", reflect.TypeOf((*MockiKeeper)(nil).getVisibleCoffins))
+}
+
 // getByName mocks base method.
 func (m *MockiKeeper) getByName(name string) *coffin {
 	m.ctrl.T.Helper()
@@ -100,6 +128,18 @@ func (mr *MockiKeeperMockRecorder) load(goner any, options ...any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "load", reflect.TypeOf((*MockiKeeper)(nil).load), varargs...)
 }
 
+// remove mocks base method.
+func (m *MockiKeeper) remove(co *coffin) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "remove", co)
+}
+
+// remove indicates an expected call of remove.
+func (mr *MockiKeeperMockRecorder) remove(co any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "remove", reflect.TypeOf((*MockiKeeper)(nil).remove), co)
+}
+
 // selectOneCoffin mocks base method.
 func (m *MockiKeeper) selectOneCoffin(t reflect.Type, pattern string, warn func()) *coffin {
 	m.ctrl.T.Helper()
@@ -168,6 +208,62 @@ func (mr *MockiDependenceAnalyzerMockRecorder) checkCircularDepsAndGetBestInitOr
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "checkCircularDepsAndGetBestInitOrder", reflect.TypeOf((*MockiDependenceAnalyzer)(nil).checkCircularDepsAndGetBestInitOrder))
 }
 
+// checkStrict mocks base method.
+func (m *MockiDependenceAnalyzer) checkStrict() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "checkStrict")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// checkStrict indicates an expected call of checkStrict.
+func (mr *MockiDependenceAnalyzerMockRecorder) checkStrict() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "checkStrict", reflect.TypeOf((*MockiDependenceAnalyzer)(nil).checkStrict))
+}
+
+// getInstallGraph mocks base method.
+func (m *MockiDependenceAnalyzer) getInstallGraph() map[dependency][]dependency {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getInstallGraph")
+	ret0, _ := ret[0].(map[dependency][]dependency)
+	return ret0
+}
+
+// getInstallGraph indicates an expected call of getInstallGraph.
+func (mr *MockiDependenceAnalyzerMockRecorder) getInstallGraph() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getInstallGraph", reflect.TypeOf((*MockiDependenceAnalyzer)(nil).getInstallGraph))
+}
+
+// getGraph mocks base method.
+func (m *MockiDependenceAnalyzer) getGraph() *Graph {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "getGraph")
+	ret0, _ := ret[0].(*Graph)
+	return ret0
+}
+
+// getGraph indicates an expected call of getGraph.
+func (mr *MockiDependenceAnalyzerMockRecorder) getGraph() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "getGraph", reflect.TypeOf((*MockiDependenceAnalyzer)(nil).getGraph))
+}
+
+// explain mocks base method.
+func (m *MockiDependenceAnalyzer) explain(field reflect.StructField, coName string) *Explanation {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "explain", field, coName)
+	ret0, _ := ret[0].(*Explanation)
+	return ret0
+}
+
+// explain indicates an expected call of explain.
+func (mr *MockiDependenceAnalyzerMockRecorder) explain(field, coName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "explain", reflect.TypeOf((*MockiDependenceAnalyzer)(nil).explain), field, coName)
+}
+
 // MockiInstaller is a mock of iInstaller interface.
 type MockiInstaller struct {
 	ctrl     *gomock.Controller
@@ -220,6 +316,21 @@ func (mr *MockiInstallerMockRecorder) injectField(asSlice, byName, extend, depCo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "injectField", reflect.TypeOf((*MockiInstaller)(nil).injectField), asSlice, byName, extend, depCoffins, field, v, coName)
 }
 
+// provide mocks base method.
+func (m *MockiInstaller) provide(co *coffin, byName bool, extend string, t reflect.Type) (any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "provide", co, byName, extend, t)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// provide indicates an expected call of provide.
+func (mr *MockiInstallerMockRecorder) provide(co, byName, extend, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "provide", reflect.TypeOf((*MockiInstaller)(nil).provide), co, byName, extend, t)
+}
+
 // safeFillOne mocks base method.
 func (m *MockiInstaller) safeFillOne(c *coffin) error {
 	m.ctrl.T.Helper()
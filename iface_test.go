@@ -171,15 +171,12 @@ func Test_install(t *testing.T) {
 		dep *Dep `gone:"*"`
 	}
 
-	t.Run("install panic", func(t *testing.T) {
-		err := SafeExecute(func() error {
-			NewApp().
-				Load(&X{}).
-				install()
-			return nil
-		})
+	t.Run("install error", func(t *testing.T) {
+		err := NewApp().
+			Load(&X{}).
+			loader.Install()
 		if err == nil {
-			t.Errorf("install panic")
+			t.Errorf("install error")
 		}
 	})
 
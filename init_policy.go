@@ -0,0 +1,205 @@
+package gone
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// InitConfigPrefix is the prefix of the config keys of the init policy of a named Goner, which are
+// "gone.init.<name>.timeout", "gone.init.<name>.retry" and "gone.init.<name>.backoff", see InitPolicyFromConfig.
+const InitConfigPrefix = "gone.init"
+
+// initPolicy limits the time of Init of a Goner, and retries Init when it fails.
+type initPolicy struct {
+	timeout time.Duration
+	retries int
+	backoff time.Duration
+}
+
+func (p initPolicy) enabled() bool {
+	return p.timeout > 0 || p.retries > 0
+}
+
+// initAttempt records one call of Init, which is reported when all attempts fail.
+type initAttempt struct {
+	elapsed time.Duration
+	err     error
+}
+
+type initTimeoutError struct {
+	timeout time.Duration
+}
+
+func (e initTimeoutError) Error() string {
+	return fmt.Sprintf("Init did not return within %s", e.timeout)
+}
+
+// initWithPolicy initializes c following its init policy, and returns an error with the history of all attempts
+// when it cannot be initialized.
+func (s *installer) initWithPolicy(c *coffin) error {
+	p := c.initPolicy
+	var attempts []initAttempt
+	backoff := p.backoff
+	for i := 0; i <= p.retries; i++ {
+		if i > 0 && backoff > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		start := time.Now()
+		err := s.initAttempt(c, p.timeout)
+		attempts = append(attempts, initAttempt{elapsed: time.Since(start), err: err})
+		if err == nil {
+			if i > 0 {
+				s.logger.Infof("%s is initialized after %d attempts", c.Name(), i+1)
+			}
+			return nil
+		}
+		if _, timeout := err.(initTimeoutError); timeout {
+			// the timed out Init is still running, so it is not called again
+			break
+		}
+		s.logger.Warnf("attempt %d to initialize %s failed: %s", i+1, c.Name(), err)
+	}
+	return initAttemptsError(c, attempts)
+}
+
+// initAttempt calls Init of c once, and returns initTimeoutError if it does not return within timeout.
+// c is marked initialized only when Init returns in time, an abandoned call never marks it.
+func (s *installer) initAttempt(c *coffin, timeout time.Duration) error {
+	if timeout <= 0 {
+		return s.initOnce(c)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- s.callInit(c)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		if err == nil {
+			c.isInit = true
+		}
+		return err
+	case <-timer.C:
+		return initTimeoutError{timeout: timeout}
+	}
+}
+
+func initAttemptsError(c *coffin, attempts []initAttempt) Error {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("failed to initialize %s after %d attempt(s):", c.Name(), len(attempts)))
+	for i, a := range attempts {
+		// the stack of an inner error is reported once, by the error of the last attempt
+		b.WriteString(fmt.Sprintf("\n\tattempt %d (%s): %s", i+1, a.elapsed.Round(time.Millisecond), errorMsg(a.err)))
+	}
+	err := newIError(attempts[len(attempts)-1].err, FailInstall, 2)
+	err.SetMsg(b.String())
+	return err
+}
+
+const initPolicyConfigName = "gone-init-policy-config"
+
+type initPolicyConfig struct {
+	Flag
+}
+
+// InitPolicyFromConfig returns a LoadFunc which enables setting the init policies of named Goners by the config keys
+// prefixed by InitConfigPrefix, see InitTimeout and InitRetry.
+//
+// The keys of a Goner are read right before it is initialized, and the Goner is initialized after the configure,
+// unless the configure depends on it, in which case the keys of it are not read.
+//
+// Example usage:
+//
+//	gone.NewApp(gone.InitPolicyFromConfig()).Load(&MqClient{}, gone.Name("mq")).Serve()
+func InitPolicyFromConfig() LoadFunc {
+	return func(loader Loader) error {
+		return loader.Load(&initPolicyConfig{}, Name(initPolicyConfigName), builtin())
+	}
+}
+
+// addInitConfigDeps makes named Goners initialized after the configure when InitPolicyFromConfig is loaded, except
+// the ones which the configure depends on.
+func (s *dependenceAnalyzer) addInitConfigDeps(deps map[dependency][]dependency) {
+	if s.iKeeper.getByName(initPolicyConfigName) == nil {
+		return
+	}
+	configure := s.iKeeper.getByName(ConfigureName)
+	if configure == nil {
+		return
+	}
+
+	used := make(map[*coffin]bool)
+	visited := make(map[dependency]bool)
+	var visit func(dep dependency)
+	visit = func(dep dependency) {
+		if visited[dep] {
+			return
+		}
+		visited[dep] = true
+		used[dep.coffin] = true
+		for _, d := range deps[dep] {
+			visit(d)
+		}
+	}
+	visit(dependency{coffin: configure, action: initAction})
+
+	for _, co := range s.iKeeper.getAllCoffins() {
+		if co.name == "" || co.builtin || co.prototype || co.requestScoped || used[co] {
+			continue
+		}
+		dep := dependency{coffin: co, action: initAction}
+		deps[dep] = append(deps[dep], dependency{coffin: configure, action: initAction})
+	}
+}
+
+// applyInitConfig overrides the init policy of c with the config keys prefixed by InitConfigPrefix, when
+// InitPolicyFromConfig is loaded and the configure is installed.
+func (s *installer) applyInitConfig(c *coffin) error {
+	if s.keeper == nil || c.name == "" || c.builtin || s.keeper.getByName(initPolicyConfigName) == nil {
+		return nil
+	}
+	co := s.keeper.getByName(ConfigureName)
+	if co == nil || co == c || !co.isInit {
+		return nil
+	}
+	configure, ok := co.goner.(Configure)
+	if !ok {
+		return NewInnerErrorWithParams(GonerTypeNotMatch, "%q does not implement Configure", ConfigureName)
+	}
+
+	prefix := fmt.Sprintf("%s.%s.", InitConfigPrefix, c.name)
+	var timeout, backoff time.Duration
+	var retries int
+	if err := configure.Get(prefix+"timeout", &timeout, "0"); err != nil {
+		return ToErrorWithMsg(err, fmt.Sprintf("failed to read %q", prefix+"timeout"))
+	}
+	if err := configure.Get(prefix+"retry", &retries, "0"); err != nil {
+		return ToErrorWithMsg(err, fmt.Sprintf("failed to read %q", prefix+"retry"))
+	}
+	if err := configure.Get(prefix+"backoff", &backoff, "0"); err != nil {
+		return ToErrorWithMsg(err, fmt.Sprintf("failed to read %q", prefix+"backoff"))
+	}
+	if timeout > 0 {
+		c.initPolicy.timeout = timeout
+	}
+	if retries > 0 {
+		c.initPolicy.retries = retries
+	}
+	if backoff > 0 {
+		c.initPolicy.backoff = backoff
+	}
+	return nil
+}
+
+// errorMsg returns the message of err without the stack of an inner error.
+func errorMsg(err error) string {
+	if e, ok := err.(Error); ok {
+		return e.Msg()
+	}
+	return err.Error()
+}
@@ -0,0 +1,169 @@
+package gone
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type flakyInitGoner struct {
+	Flag
+	failures int
+	calls    int
+}
+
+func (g *flakyInitGoner) Init() error {
+	g.calls++
+	if g.calls <= g.failures {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+type slowInitGoner struct {
+	Flag
+	delay time.Duration
+}
+
+func (g *slowInitGoner) Init() {
+	time.Sleep(g.delay)
+}
+
+func TestInitRetry(t *testing.T) {
+	t.Run("succeed after retries", func(t *testing.T) {
+		g := &flakyInitGoner{failures: 2}
+		err := NewApp().Load(g, InitRetry(3, time.Millisecond)).loader.Install()
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if g.calls != 3 {
+			t.Errorf("Init should be called 3 times, got %d", g.calls)
+		}
+	})
+
+	t.Run("fail with attempt history", func(t *testing.T) {
+		g := &flakyInitGoner{failures: 10}
+		err := NewApp().Load(g, Name("mq"), InitRetry(2, 0)).loader.Install()
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if g.calls != 3 {
+			t.Errorf("Init should be called 3 times, got %d", g.calls)
+		}
+		var iErr Error
+		if !errors.As(err, &iErr) || iErr.Code() != FailInstall {
+			t.Errorf("unexpected error %v", err)
+		}
+		msg := err.Error()
+		for _, s := range []string{"failed to initialize Goner(name=mq) after 3 attempt(s)", "attempt 1", "attempt 3", "connection refused"} {
+			if !strings.Contains(msg, s) {
+				t.Errorf("error should contain %q, got %s", s, msg)
+			}
+		}
+	})
+
+	t.Run("negative retries", func(t *testing.T) {
+		err := NewApp().loader.Load(&flakyInitGoner{}, InitRetry(-1, 0))
+		if err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+type blockedInitGoner struct {
+	Flag
+	release  chan struct{}
+	returned chan struct{}
+}
+
+func (g *blockedInitGoner) Init() {
+	<-g.release
+	close(g.returned)
+}
+
+func TestInitTimeout(t *testing.T) {
+	t.Run("timeout", func(t *testing.T) {
+		err := NewApp().Load(&slowInitGoner{delay: 200 * time.Millisecond}, InitTimeout(10*time.Millisecond), InitRetry(3, 0)).loader.Install()
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		msg := err.Error()
+		if !strings.Contains(msg, "after 1 attempt(s)") || !strings.Contains(msg, "did not return within 10ms") {
+			t.Errorf("unexpected error %s", msg)
+		}
+	})
+
+	t.Run("abandoned", func(t *testing.T) {
+		g := &blockedInitGoner{release: make(chan struct{}), returned: make(chan struct{})}
+		app := NewApp().Load(g, InitTimeout(10*time.Millisecond))
+		if err := app.loader.Install(); err == nil {
+			t.Fatal("expected an error")
+		}
+		close(g.release)
+		<-g.returned
+		time.Sleep(10 * time.Millisecond)
+		for _, co := range app.loader.iKeeper.getAllCoffins() {
+			if co.goner == g && co.isInit {
+				t.Error("goner whose Init is abandoned should not be marked initialized")
+			}
+		}
+	})
+
+	t.Run("in time", func(t *testing.T) {
+		err := NewApp().Load(&slowInitGoner{}, InitTimeout(time.Second)).loader.Install()
+		if err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+
+	t.Run("non positive timeout", func(t *testing.T) {
+		err := NewApp().loader.Load(&slowInitGoner{}, InitTimeout(0))
+		if err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
+
+func TestInitPolicyConfig(t *testing.T) {
+	t.Setenv("GONE_GONE_INIT_MQ_RETRY", "2")
+	t.Setenv("GONE_GONE_INIT_MQ_BACKOFF", "1ms")
+
+	g := &flakyInitGoner{failures: 2}
+	err := NewApp(InitPolicyFromConfig()).Load(g, Name("mq")).loader.Install()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if g.calls != 3 {
+		t.Errorf("Init should be called 3 times, got %d", g.calls)
+	}
+
+	t.Setenv("GONE_GONE_INIT_SLOW_TIMEOUT", "10ms")
+	err = NewApp(InitPolicyFromConfig()).Load(&slowInitGoner{delay: 200 * time.Millisecond}, Name("slow")).loader.Install()
+	if err == nil || !strings.Contains(err.Error(), "did not return within 10ms") {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	g = &flakyInitGoner{failures: 1}
+	err = NewApp().Load(g, Name("mq")).loader.Install()
+	if err == nil || g.calls != 1 {
+		t.Errorf("config should be ignored without InitPolicyFromConfig, got %v after %d calls", err, g.calls)
+	}
+}
+
+func TestInitPolicyConfig_configureDependingOnInitiator(t *testing.T) {
+	source := &strictConfigSource{}
+	configure := &strictConfigure{}
+	g := &flakyInitGoner{}
+	err := NewApp(InitPolicyFromConfig()).
+		Load(source, Name("source")).
+		Load(configure, Name(ConfigureName), ForceReplace()).
+		Load(g, Name("mq")).
+		loader.Install()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !configure.ready || g.calls != 1 {
+		t.Errorf("goners are not installed in dependency order")
+	}
+}
@@ -0,0 +1,119 @@
+package gone
+
+import (
+	"sort"
+)
+
+// CoffinInfo describes a Goner loaded into the Gone container, see Inspector.
+type CoffinInfo struct {
+	// Name is the name of the Goner, it is empty for Goners loaded without a name.
+	Name string
+
+	// Type is the concrete type of the Goner, or of the value created by it for constructors.
+	Type string
+
+	// ProvidedType is the type of values supplied by the Goner if it is a Provider, otherwise it is empty.
+	ProvidedType string
+
+	Order         int
+	OnlyForName   bool
+	LazyFill      bool
+	Prototype     bool
+	RequestScoped bool
+	Fallback      bool
+
+	// Builtin is true for Goners loaded by gone itself.
+	Builtin bool
+
+	// DefaultTypes are the types for which the Goner is the default one, see IsDefault.
+	DefaultTypes []string
+
+	// Options describe all the options the Goner is loaded with, in the same format as GraphNode.Options.
+	Options []string
+
+	Filled      bool
+	Initialized bool
+
+	// Injections are the fields of the Goner injected so far, in the order they are injected.
+	Injections []InjectionInfo
+}
+
+// InjectionInfo records that the field of a Goner is injected from the Goners From, which are more than one
+// for slices and maps.
+type InjectionInfo struct {
+	Field string
+	From  []string
+}
+
+// injection is a field of a coffin injected from coffins, see Inspector.
+type injection struct {
+	field string
+	from  []*coffin
+}
+
+// recordInjection records that field of co is injected from coffins. Deferred fields are injected when other
+// Goners are initialized, which may happen concurrently with ParallelInit.
+func (c *coffin) recordInjection(field string, from []*coffin) {
+	c.injectionsMutex.Lock()
+	defer c.injectionsMutex.Unlock()
+	c.injections = append(c.injections, injection{field: field, from: from})
+}
+
+type inspector struct {
+	Flag
+	keeper iKeeper
+}
+
+func (s *inspector) Coffins() []CoffinInfo {
+	coffins := s.keeper.getAllCoffins()
+	infos := make([]CoffinInfo, 0, len(coffins))
+	for _, co := range coffins {
+		infos = append(infos, newCoffinInfo(co))
+	}
+	return infos
+}
+
+func (s *inspector) CoffinByName(name string) (CoffinInfo, bool) {
+	co := s.keeper.getByName(name)
+	if co == nil {
+		return CoffinInfo{}, false
+	}
+	return newCoffinInfo(co), true
+}
+
+func newCoffinInfo(co *coffin) CoffinInfo {
+	info := CoffinInfo{
+		Name:          co.name,
+		Type:          coffinTypeName(co),
+		ProvidedType:  providedTypeName(co),
+		Order:         co.order,
+		OnlyForName:   co.onlyForName,
+		LazyFill:      co.lazyFill,
+		Prototype:     co.prototype,
+		RequestScoped: co.requestScoped,
+		Fallback:      co.fallback,
+		Builtin:       co.builtin,
+		Options:       coffinOptions(co),
+		Filled:        co.isFill,
+		Initialized:   co.isInit,
+	}
+	for t, ok := range co.defaultTypeMap {
+		if ok {
+			info.DefaultTypes = append(info.DefaultTypes, GetTypeName(t))
+		}
+	}
+	sort.Strings(info.DefaultTypes)
+
+	co.injectionsMutex.Lock()
+	defer co.injectionsMutex.Unlock()
+	for _, in := range co.injections {
+		from := make([]string, 0, len(in.from))
+		for _, c := range in.from {
+			from = append(from, c.Name())
+		}
+		info.Injections = append(info.Injections, InjectionInfo{Field: in.field, From: from})
+	}
+	return info
+}
+
+var _ Inspector = (*inspector)(nil)
@@ -0,0 +1,99 @@
+package gone
+
+import (
+	"strings"
+	"testing"
+)
+
+type inspectedDb struct {
+	Flag
+}
+
+type inspectedNamer interface {
+	InspectedName() string
+}
+
+type inspectedNamerProvider struct {
+	Flag
+}
+
+func (p *inspectedNamerProvider) Provide() (inspectedNamer, error) {
+	return &inspectedNamerImpl{}, nil
+}
+
+type inspectedNamerImpl struct{}
+
+func (n *inspectedNamerImpl) InspectedName() string {
+	return "namer"
+}
+
+type inspectedRepo struct {
+	Flag
+	db     *inspectedDb             `gone:"db"`
+	namer  inspectedNamer           `gone:"*"`
+	all    []*inspectedDb           `gone:"*"`
+	maybe  Optional[*inspectedDb]   `gone:"db2"`
+	absent Optional[inspectedNamer] `gone:"absent"`
+}
+
+func TestInspector(t *testing.T) {
+	var inspector Inspector
+	NewApp().
+		Load(&inspectedDb{}, Name("db"), Order(-1), IsDefault()).
+		Load(&inspectedDb{}, Name("db2"), OnlyForName()).
+		Load(&inspectedNamerProvider{}, LazyFill()).
+		Load(&inspectedRepo{}, Name("repo")).
+		Run(func(i Inspector) {
+			inspector = i
+		})
+
+	infos := inspector.Coffins()
+	if len(infos) == 0 || !infos[0].Builtin {
+		t.Fatalf("builtin goners should be listed first, got %v", infos)
+	}
+
+	db, ok := inspector.CoffinByName("db")
+	if !ok {
+		t.Fatal("db should be found")
+	}
+	if db.Type != "*"+graphPkg+".inspectedDb" || db.Order != -1 || !db.Filled || !db.Initialized || db.Builtin {
+		t.Errorf("unexpected info %+v", db)
+	}
+	if strings.Join(db.DefaultTypes, ",") != "*"+graphPkg+".inspectedDb" {
+		t.Errorf("unexpected default types %v", db.DefaultTypes)
+	}
+
+	db2, _ := inspector.CoffinByName("db2")
+	if !db2.OnlyForName {
+		t.Errorf("unexpected info %+v", db2)
+	}
+
+	var provider CoffinInfo
+	for _, info := range infos {
+		if info.Type == "*"+graphPkg+".inspectedNamerProvider" {
+			provider = info
+		}
+	}
+	if provider.ProvidedType != graphPkg+".inspectedNamer" || !provider.LazyFill {
+		t.Errorf("unexpected info %+v", provider)
+	}
+
+	repo, _ := inspector.CoffinByName("repo")
+	var injections []string
+	for _, in := range repo.Injections {
+		injections = append(injections, in.Field+"<-"+strings.Join(in.From, ","))
+	}
+	want := []string{
+		"db<-Goner(name=db)",
+		"namer<-*gone.inspectedNamerProvider",
+		"all<-Goner(name=db)",
+		"maybe<-Goner(name=db2)",
+	}
+	if strings.Join(injections, " ") != strings.Join(want, " ") {
+		t.Errorf("unexpected injections %v, want %v", injections, want)
+	}
+
+	if _, ok = inspector.CoffinByName("missing"); ok {
+		t.Error("missing goner should not be found")
+	}
+}
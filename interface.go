@@ -1,6 +1,7 @@
 package gone
 
 import (
+	"context"
 	"reflect"
 	"sync"
 )
@@ -202,8 +203,10 @@ type StructFieldInjector interface {
 // to ensure proper cleanup and resource management.
 //
 // Error Handling:
-// - If Start() returns an error, the application will panic to prevent inconsistent state
-// - If Stop() returns an error, the application will panic to ensure proper cleanup
+// - If Start() returns an error, the daemons already started are stopped, and the error is reported with the
+//   exit code ExitStartFailure, see ExitCode: Run panics with it, while RunE returns it
+// - If Stop() returns an error, the other daemons are still stopped, and all errors are reported together
+//   as a MultiError: Run panics with it, while RunE returns it
 //
 // Example usage:
 // ```go
@@ -230,6 +233,40 @@ type Daemon interface {
 	Stop() error
 }
 
+// ContextDaemon is a Daemon whose Start and Stop receive a context.Context.
+// Stop receives a context which is done when the shutdown timeout, configured by "gone.shutdown.timeout", expires;
+// a ContextDaemon should return from Stop as soon as possible after that.
+//
+// ContextDaemons and Daemons are started together in order of Order() value and then loading order, and stopped
+// in reverse order.
+// If a Daemon or ContextDaemon does not stop before the shutdown timeout expires, it and the remaining daemons
+// are abandoned, and the application stops with an error listing the daemons which hung.
+//
+// Example usage:
+// ```go
+//
+//	type Worker struct {
+//	    gone.Flag
+//	    done chan struct{}
+//	}
+//
+//	func (w *Worker) Start(ctx context.Context) error {
+//	    w.done = make(chan struct{})
+//	    go w.loop()
+//	    return nil
+//	}
+//
+//	func (w *Worker) Stop(ctx context.Context) error {
+//	    close(w.done)
+//	    return nil
+//	}
+//
+// ```
+type ContextDaemon interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
 // FuncInjector provides methods for injecting dependencies into function parameters.
 // Think of it as an "intelligent assistant" that automatically identifies what parameters
 // a function needs, then finds the corresponding components from the "warehouse" and
@@ -439,6 +476,36 @@ type Loader interface {
 	Loaded(LoaderKey) bool
 }
 
+// ConstructorLoader interface is implemented by Loaders which can register constructor functions, like the Loader
+// passed to LoadFuncs by Application. It is separated from Loader, so that existing implementations of Loader keep
+// working; a LoadFunc type-asserts the Loader for it.
+//
+// Example usage:
+//
+// ```go
+//
+//	func loadDb(loader gone.Loader) error {
+//	    if l, ok := loader.(gone.ConstructorLoader); ok {
+//	        return l.LoadConstructor(newDb)
+//	    }
+//	    return errors.New("constructors are not supported")
+//	}
+//
+// ```
+type ConstructorLoader interface {
+	// LoadConstructor registers a constructor function, which is called during installation to create a component.
+	// The parameters of the constructor are injected like the parameters of functions run by the Application,
+	// and the constructed value can be injected wherever its type is required.
+	//
+	// Parameters:
+	//   - fn: A function returning a value, or a value and an error, like func(deps...) (*T, error)
+	//   - options: Optional configuration for how the component should be loaded.
+	//
+	// Returns:
+	//   - error: Any error that occurred during loading
+	LoadConstructor(fn any, options ...Option) error
+}
+
 // GonerKeeper interface defines methods for retrieving components from the Gone container.
 // Think of it as a super-intelligent "archive manager" who knows the "home address" of every
 // component in the system. Whether you want to find someone by "name" or by "profession" (type),
@@ -516,3 +583,31 @@ type GonerKeeper interface {
 }
 
 type Keeper = GonerKeeper
+
+// Inspector interface provides a read-only view of all the Goners loaded into the Gone container,
+// including how they are loaded, whether they are installed, and where their fields are injected from.
+// It is useful for diagnostics, admin endpoints and tests.
+//
+// Example usage:
+//
+// ```go
+//
+//	type Admin struct {
+//	    gone.Flag
+//	    inspector gone.Inspector `gone:"*"`
+//	}
+//
+//	func (a *Admin) Dump() {
+//	    for _, info := range a.inspector.Coffins() {
+//	        fmt.Println(info.Name, info.Type, info.Initialized)
+//	    }
+//	}
+//
+// ```
+type Inspector interface {
+	// Coffins returns the information of all the loaded Goners, in loading order.
+	Coffins() []CoffinInfo
+
+	// CoffinByName returns the information of the Goner with the name, and false if there is no such Goner.
+	CoffinByName(name string) (CoffinInfo, bool)
+}
@@ -0,0 +1,141 @@
+package gone
+
+import (
+	"fmt"
+	"reflect"
+)
+
+const allowInterfaceCyclesName = "gone-allow-interface-cycles"
+
+type allowInterfaceCycles struct {
+	Flag
+}
+
+// AllowInterfaceCycles returns a LoadFunc which enables breaking circular dependencies on interface-typed fields.
+//
+// Without it, a circular dependency fails the installation. With it, when a cycle contains a Goner which needs an
+// initialized value (for example a value supplied by a Provider) for an interface-typed field, the field is left
+// out of the init order: the Goner is filled and initialized without it, and the field is injected right after
+// the value it depends on is initialized. Go cannot create a forwarding proxy implementing an arbitrary interface
+// at runtime, so the field stays nil until then, which means it must not be used in Init or BeforeInit; calls made
+// after the installation see the resolved value, the same as through a proxy.
+//
+// Cycles formed by parameters of constructors, or by fields of other types, still fail the installation.
+//
+// Example usage:
+//
+//	gone.NewApp(gone.AllowInterfaceCycles()).Load(&OrderServiceProvider{}).Load(&PaymentServiceProvider{})
+func AllowInterfaceCycles() LoadFunc {
+	return func(loader Loader) error {
+		return loader.Load(&allowInterfaceCycles{}, Name(allowInterfaceCyclesName), builtin())
+	}
+}
+
+// deferredInjection is an interface-typed field of co, which is injected after the value it depends on is initialized.
+type deferredInjection struct {
+	co    *coffin
+	field reflect.StructField
+}
+
+// breakInterfaceCycle removes an edge of the cycle, which is caused only by interface-typed fields, from deps.
+// It returns false if there is no such edge.
+func (s *dependenceAnalyzer) breakInterfaceCycle(deps map[dependency][]dependency, cycle []dependency) bool {
+	for i := 0; i+1 < len(cycle); i++ {
+		from, to := cycle[i], cycle[i+1]
+		if from.action != fillAction || to.action != initAction {
+			continue
+		}
+		fields := s.getInterfaceFieldsDependingOn(from.coffin, to.coffin)
+		if len(fields) == 0 {
+			continue
+		}
+
+		var rest []dependency
+		for _, dep := range deps[from] {
+			if dep != to {
+				rest = append(rest, dep)
+			}
+		}
+		deps[from] = rest
+
+		if from.coffin.deferredFields == nil {
+			from.coffin.deferredFields = make(map[string]bool)
+		}
+		for _, field := range fields {
+			if from.coffin.deferredFields[field.Name] {
+				// deferred by an earlier check
+				continue
+			}
+			from.coffin.deferredFields[field.Name] = true
+			to.coffin.deferredInjections = append(to.coffin.deferredInjections, deferredInjection{co: from.coffin, field: field})
+		}
+		s.logger.Debugf("break circular dependency: fields %v of %s are injected after %s is initialized", fields, from.coffin.Name(), to.coffin.Name())
+		return true
+	}
+	return false
+}
+
+// removeDeferredEdges removes the edges broken by an earlier check from deps, so that checking again breaks the same
+// edges instead of others. It returns false if there is no such edge.
+func (s *dependenceAnalyzer) removeDeferredEdges(deps map[dependency][]dependency) (removed bool) {
+	for _, co := range s.iKeeper.getAllCoffins() {
+		to := dependency{coffin: co, action: initAction}
+		for _, d := range co.deferredInjections {
+			from := dependency{coffin: d.co, action: fillAction}
+			var rest []dependency
+			for _, dep := range deps[from] {
+				if dep != to {
+					rest = append(rest, dep)
+				}
+			}
+			removed = removed || len(rest) < len(deps[from])
+			deps[from] = rest
+		}
+	}
+	return removed
+}
+
+// getInterfaceFieldsDependingOn returns the fields of co which depend on target, if all of them are interface-typed.
+func (s *dependenceAnalyzer) getInterfaceFieldsDependingOn(co *coffin, target *coffin) (fields []reflect.StructField) {
+	elem := reflect.TypeOf(co.goner).Elem()
+	if elem.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < elem.NumField(); i++ {
+		field := elem.Field(i)
+		depends, breakable := false, true
+		_ = s.analyzeFieldDependencies(field, co.Name(), nil, func(asSlice, byName bool, extend string, coffins ...*coffin) error {
+			for _, c := range coffins {
+				if c == target {
+					depends = true
+					breakable = !asSlice && field.Type.Kind() == reflect.Interface
+				}
+			}
+			return nil
+		})
+		if depends && !breakable {
+			return nil
+		}
+		if depends {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// injectDeferredFields injects the fields deferred until co is initialized.
+func (s *core) injectDeferredFields(co *coffin) error {
+	for _, d := range co.deferredInjections {
+		v := reflect.ValueOf(d.co.goner).Elem().FieldByIndex(d.field.Index)
+		if err := s.iInstaller.analyzerFieldDependencies(d.field, d.co.Name(), func(asSlice, byName bool, extend string, coffins ...*coffin) error {
+			if err := s.iInstaller.injectField(asSlice, byName, extend, coffins, d.field, v, d.co.Name()); err != nil {
+				return err
+			}
+			d.co.recordInjection(d.field.Name, coffins)
+			return nil
+		}); err != nil {
+			return ToErrorWithMsg(err, fmt.Sprintf("failed to inject deferred field %q of %s", d.field.Name, d.co.Name()))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package gone
+
+import (
+	"strings"
+	"testing"
+)
+
+type cycleOrders interface {
+	Orders() string
+}
+
+type cyclePayments interface {
+	Payments() string
+}
+
+type cycleOrderService struct {
+	payments cyclePayments
+}
+
+func (s *cycleOrderService) Orders() string {
+	return "orders"
+}
+
+type cyclePaymentService struct {
+	orders cycleOrders
+}
+
+func (s *cyclePaymentService) Payments() string {
+	return "payments"
+}
+
+type cycleOrderProvider struct {
+	Flag
+	payments cyclePayments `gone:"*"`
+	service  *cycleOrderService
+}
+
+func (p *cycleOrderProvider) Init() {
+	p.service = &cycleOrderService{}
+}
+
+func (p *cycleOrderProvider) Provide() (cycleOrders, error) {
+	p.service.payments = p.payments
+	return p.service, nil
+}
+
+type cyclePaymentProvider struct {
+	Flag
+	orders  cycleOrders `gone:"*"`
+	service *cyclePaymentService
+}
+
+func (p *cyclePaymentProvider) Init() {
+	p.service = &cyclePaymentService{orders: p.orders}
+}
+
+func (p *cyclePaymentProvider) Provide() (cyclePayments, error) {
+	return p.service, nil
+}
+
+func TestAllowInterfaceCycles(t *testing.T) {
+	t.Run("fail without AllowInterfaceCycles", func(t *testing.T) {
+		err := NewApp().
+			Load(&cycleOrderProvider{}).
+			Load(&cyclePaymentProvider{}).
+			loader.Install()
+		if err == nil || !strings.Contains(err.Error(), "circular dependency") {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+
+	t.Run("break the cycle", func(t *testing.T) {
+		NewApp(AllowInterfaceCycles()).
+			Load(&cycleOrderProvider{}).
+			Load(&cyclePaymentProvider{}).
+			Run(func(orders cycleOrders, payments cyclePayments, op *cycleOrderProvider, pp *cyclePaymentProvider) {
+				if op.payments == nil || pp.orders == nil {
+					t.Fatal("fields of both providers should be injected")
+				}
+				if op.payments.Payments() != "payments" || pp.orders.Orders() != "orders" {
+					t.Error("unexpected injected values")
+				}
+			})
+	})
+
+	t.Run("check twice", func(t *testing.T) {
+		op, pp := &cycleOrderProvider{}, &cyclePaymentProvider{}
+		app := NewApp(AllowInterfaceCycles()).Load(op).Load(pp)
+		for i := 0; i < 2; i++ {
+			if _, err := app.loader.Check(); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+		}
+		var deferred int
+		for _, co := range app.loader.iKeeper.getAllCoffins() {
+			deferred += len(co.deferredInjections)
+		}
+		if deferred != 1 {
+			t.Errorf("the field should be deferred once, got %d", deferred)
+		}
+		if err := app.loader.Install(); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if op.payments == nil || pp.orders == nil {
+			t.Error("fields of both providers should be injected")
+		}
+	})
+
+	t.Run("cycle of non interface fields", func(t *testing.T) {
+		type a struct {
+			Flag
+			b *cycleOrderService `gone:"*"`
+		}
+		err := NewApp(AllowInterfaceCycles()).
+			LoadConstructor(func(s cyclePayments) *cycleOrderService {
+				return &cycleOrderService{payments: s}
+			}).
+			LoadConstructor(func(s *cycleOrderService) cyclePayments {
+				return &cyclePaymentService{}
+			}).
+			Load(&a{}).
+			loader.Install()
+		if err == nil || !strings.Contains(err.Error(), "circular dependency") {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+}
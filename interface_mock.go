@@ -10,6 +10,7 @@
 package gone
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -384,6 +385,58 @@ func (mr *MockDaemonMockRecorder) Stop() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockDaemon)(nil).Stop))
 }
 
+// MockContextDaemon is a mock of ContextDaemon interface.
+type MockContextDaemon struct {
+	ctrl     *gomock.Controller
+	recorder *MockContextDaemonMockRecorder
+	isgomock struct{}
+}
+
+// MockContextDaemonMockRecorder is the mock recorder for MockContextDaemon.
+type MockContextDaemonMockRecorder struct {
+	mock *MockContextDaemon
+}
+
+// NewMockContextDaemon creates a new mock instance.
+func NewMockContextDaemon(ctrl *gomock.Controller) *MockContextDaemon {
+	mock := &MockContextDaemon{ctrl: ctrl}
+	mock.recorder = &MockContextDaemonMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContextDaemon) EXPECT() *MockContextDaemonMockRecorder {
+	return m.recorder
+}
+
+// Start mocks base method.
+func (m *MockContextDaemon) Start(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockContextDaemonMockRecorder) Start(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockContextDaemon)(nil).Start), ctx)
+}
+
+// Stop mocks base method.
+func (m *MockContextDaemon) Stop(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockContextDaemonMockRecorder) Stop(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockContextDaemon)(nil).Stop), ctx)
+}
+
 // MockFuncInjector is a mock of FuncInjector interface.
 type MockFuncInjector struct {
 	ctrl     *gomock.Controller
@@ -566,6 +619,49 @@ func (mr *MockLoaderMockRecorder) MustLoadX(x any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MustLoadX", reflect.TypeOf((*MockLoader)(nil).MustLoadX), x)
 }
 
+// MockConstructorLoader is a mock of ConstructorLoader interface.
+type MockConstructorLoader struct {
+	ctrl     *gomock.Controller
+	recorder *MockConstructorLoaderMockRecorder
+	isgomock struct{}
+}
+
+// MockConstructorLoaderMockRecorder is the mock recorder for MockConstructorLoader.
+type MockConstructorLoaderMockRecorder struct {
+	mock *MockConstructorLoader
+}
+
+// NewMockConstructorLoader creates a new mock instance.
+func NewMockConstructorLoader(ctrl *gomock.Controller) *MockConstructorLoader {
+	mock := &MockConstructorLoader{ctrl: ctrl}
+	mock.recorder = &MockConstructorLoaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConstructorLoader) EXPECT() *MockConstructorLoaderMockRecorder {
+	return m.recorder
+}
+
+// LoadConstructor mocks base method.
+func (m *MockConstructorLoader) LoadConstructor(fn any, options ...Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{fn}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LoadConstructor", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LoadConstructor indicates an expected call of LoadConstructor.
+func (mr *MockConstructorLoaderMockRecorder) LoadConstructor(fn any, options ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{fn}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadConstructor", reflect.TypeOf((*MockConstructorLoader)(nil).LoadConstructor), varargs...)
+}
+
 // MockGonerKeeper is a mock of GonerKeeper interface.
 type MockGonerKeeper struct {
 	ctrl     *gomock.Controller
@@ -631,3 +727,56 @@ func (mr *MockGonerKeeperMockRecorder) GetGonerByType(t any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGonerByType", reflect.TypeOf((*MockGonerKeeper)(nil).GetGonerByType), t)
 }
+
+// MockInspector is a mock of Inspector interface.
+type MockInspector struct {
+	ctrl     *gomock.Controller
+	recorder *MockInspectorMockRecorder
+	isgomock struct{}
+}
+
+// MockInspectorMockRecorder is the mock recorder for MockInspector.
+type MockInspectorMockRecorder struct {
+	mock *MockInspector
+}
+
+// NewMockInspector creates a new mock instance.
+func NewMockInspector(ctrl *gomock.Controller) *MockInspector {
+	mock := &MockInspector{ctrl: ctrl}
+	mock.recorder = &MockInspectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInspector) EXPECT() *MockInspectorMockRecorder {
+	return m.recorder
+}
+
+// CoffinByName mocks base method.
+func (m *MockInspector) CoffinByName(name string) (CoffinInfo, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CoffinByName", name)
+	ret0, _ := ret[0].(CoffinInfo)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// CoffinByName indicates an expected call of CoffinByName.
+func (mr *MockInspectorMockRecorder) CoffinByName(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CoffinByName", reflect.TypeOf((*MockInspector)(nil).CoffinByName), name)
+}
+
+// Coffins mocks base method.
+func (m *MockInspector) Coffins() []CoffinInfo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Coffins")
+	ret0, _ := ret[0].([]CoffinInfo)
+	return ret0
+}
+
+// Coffins indicates an expected call of Coffins.
+func (mr *MockInspectorMockRecorder) Coffins() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Coffins", reflect.TypeOf((*MockInspector)(nil).Coffins))
+}
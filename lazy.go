@@ -0,0 +1,122 @@
+package gone
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Lazy is a handle of a value of type T, which is resolved from the keeper when Get is called for the first time,
+// and cached for later calls. A field typed Lazy[T], or func() (T, error), with a gone tag is injected with such
+// a handle; the tag works the same way as for a field typed T, including names, patterns and allowNil.
+//
+// Unlike the `option:"lazy"` tag, which only removes the field from the init order but still fills it eagerly,
+// a lazy handle resolves nothing when it is injected, so the Goners it resolves are not dependencies of the Goner
+// holding it, which can break real init cycles. The resolved Goners are still installed in the install order like
+// any other Goners, Lazy only defers looking them up; Providers and decorators are called on the first Get.
+//
+// Get returns an error if a resolved Goner is not filled yet, or not initialized yet while it has an init action in
+// the install order, so it must not be called before the Goners it resolves are installed, for example in the Init
+// method of a Goner installed earlier than them, like a Goner which those Goners depend on, or any Goner when they
+// are loaded with LowStartPriority.
+//
+// Example usage:
+//
+//	type Service struct {
+//	    gone.Flag
+//	    db    gone.Lazy[*sql.DB]     `gone:"*"`
+//	    cache func() (Cache, error) `gone:"*"`
+//	}
+//
+//	func (s *Service) Query() error {
+//	    db, err := s.db.Get()
+//	    ...
+//	}
+type Lazy[T any] func() (T, error)
+
+// Get resolves the value on the first successful call, and returns the cached value afterwards.
+// It fails if the Goners resolving the value are not installed yet, see Lazy.
+func (l Lazy[T]) Get() (T, error) {
+	return l()
+}
+
+// MustGet is similar to Get but panics if the value cannot be resolved.
+func (l Lazy[T]) MustGet() T {
+	v, err := l()
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// lazyTargetField returns a field of type T standing for the value resolved by a lazy handle field,
+// whose type is Lazy[T] or func() (T, error).
+func lazyTargetField(field reflect.StructField) (reflect.StructField, bool) {
+	t := field.Type
+	if t.Kind() != reflect.Func || t.NumIn() != 0 || t.NumOut() != 2 || t.Out(1) != errType {
+		return field, false
+	}
+	target := field
+	target.Type = t.Out(0)
+	return target, true
+}
+
+// injectLazyHandle injects a lazy handle into v, which resolves the value of target when it is called.
+func (s *installer) injectLazyHandle(target reflect.StructField, v reflect.Value, coName string) {
+	if !target.IsExported() {
+		v = BlackMagic(v)
+	}
+
+	var mu sync.Mutex
+	var value reflect.Value
+	handle := reflect.MakeFunc(v.Type(), func([]reflect.Value) []reflect.Value {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if !value.IsValid() {
+			rv := reflect.New(target.Type).Elem()
+			err := s.iDependenceAnalyzer.analyzerFieldDependencies(target, coName,
+				func(asSlice, byName bool, extend string, coffins ...*coffin) error {
+					for _, co := range coffins {
+						if !co.prototype && !co.requestScoped && !s.isReady(co) {
+							return NewInnerErrorWithParams(NotSupport,
+								"%s is not initialized yet, it can be resolved after it is installed", co.Name())
+						}
+					}
+					return s.injectField(asSlice, byName, extend, coffins, target, rv, coName)
+				},
+			)
+			if err != nil {
+				err = ToErrorWithMsg(err, fmt.Sprintf("failed to resolve lazy field %q of %q", target.Name, coName))
+				return []reflect.Value{reflect.Zero(target.Type), reflect.ValueOf(&err).Elem()}
+			}
+			value = rv
+		}
+		return []reflect.Value{value, reflect.Zero(errType)}
+	})
+	v.Set(handle)
+}
+
+// isReady tells whether co can be resolved by a lazy handle: it is filled, and it is initialized if it has an init
+// action in the install graph. Goners without an init action, like a Goner loaded with LazyFill which no Goner
+// depends on, are ready once they are filled.
+func (s *installer) isReady(co *coffin) bool {
+	if !co.isFill {
+		return false
+	}
+	if co.isInit {
+		return true
+	}
+	initDep := dependency{coffin: co, action: initAction}
+	for d, deps := range s.iDependenceAnalyzer.getInstallGraph() {
+		if d == initDep {
+			return false
+		}
+		for _, dep := range deps {
+			if dep == initDep {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,141 @@
+package gone
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type lazyTarget struct {
+	Flag
+	inited bool
+}
+
+func (l *lazyTarget) Init() {
+	l.inited = true
+}
+
+type lazyMissing interface {
+	missing()
+}
+
+func TestLazy(t *testing.T) {
+	type lazyUser struct {
+		Flag
+		target  Lazy[*lazyTarget]           `gone:"*"`
+		fn      func() (*lazyTarget, error) `gone:"*"`
+		missing Lazy[lazyMissing]           `gone:"*"`
+		allow   Lazy[lazyMissing]           `gone:"*" option:"allowNil"`
+		noTag   func() (*lazyTarget, error)
+	}
+
+	var calls int
+	NewApp().
+		Load(&lazyUser{}).
+		LoadConstructor(func(target *lazyTarget) *lazyTarget {
+			calls++
+			return target
+		}, Name("constructed")).
+		Load(&lazyTarget{}, Name("target"), IsDefault()).
+		Run(func(u *lazyUser) {
+			if calls != 1 {
+				t.Errorf("unexpected calls %d", calls)
+			}
+			v1, err := u.target.Get()
+			if err != nil || !v1.inited {
+				t.Errorf("lazy handle should resolve the initialized value, err = %v", err)
+			}
+			v2, err := u.fn()
+			if err != nil || v2 != v1 || u.target.MustGet() != v1 {
+				t.Errorf("func handle should resolve the same value, err = %v", err)
+			}
+
+			if _, err = u.missing.Get(); err == nil || !strings.Contains(err.Error(), `failed to resolve lazy field "missing"`) {
+				t.Errorf("unexpected error %v", err)
+			}
+			if v, err := u.allow.Get(); err != nil || v != nil {
+				t.Errorf("allowNil lazy handle should resolve nil, got %v, %v", v, err)
+			}
+			if u.noTag != nil {
+				t.Error("field without gone tag should not be injected")
+			}
+
+			defer func() {
+				if recover() == nil {
+					t.Error("MustGet should panic")
+				}
+			}()
+			u.missing.MustGet()
+		})
+}
+
+func Test_lazyTargetField(t *testing.T) {
+	var x struct {
+		a Lazy[*lazyTarget]
+		b func() (*lazyTarget, error)
+		c func() *lazyTarget
+		d func(int) (*lazyTarget, error)
+	}
+	of := reflect.TypeOf(x)
+	for i, want := range []bool{true, true, false, false} {
+		target, ok := lazyTargetField(of.Field(i))
+		if ok != want {
+			t.Errorf("field %s: lazyTargetField() = %v, want %v", of.Field(i).Name, ok, want)
+		}
+		if ok && target.Type != reflect.TypeOf(&lazyTarget{}) {
+			t.Errorf("unexpected target type %v", target.Type)
+		}
+	}
+}
+
+type lazyDependentTarget struct {
+	Flag
+	user   *lazyEarlyUser `gone:"*"`
+	inited bool
+}
+
+func (l *lazyDependentTarget) Init() {
+	l.inited = true
+}
+
+type lazyEarlyUser struct {
+	Flag
+	target Lazy[*lazyDependentTarget] `gone:"*"`
+	err    error
+}
+
+func (u *lazyEarlyUser) Init() {
+	_, u.err = u.target.Get()
+}
+
+func TestLazy_getBeforeInstalled(t *testing.T) {
+	u := &lazyEarlyUser{}
+	target := &lazyDependentTarget{}
+	NewApp().
+		Load(u).
+		Load(target).
+		Run(func() {
+			if u.err == nil || !strings.Contains(u.err.Error(), "is not initialized yet") {
+				t.Errorf("unexpected error %v", u.err)
+			}
+			if v, err := u.target.Get(); err != nil || v != target || !v.inited {
+				t.Errorf("lazy handle should resolve the initialized value after installing, err = %v", err)
+			}
+		})
+}
+
+func TestLazy_lazyFillTarget(t *testing.T) {
+	type lazyUser struct {
+		Flag
+		target Lazy[*lazyTarget] `gone:"*"`
+	}
+	target := &lazyTarget{}
+	NewApp().
+		Load(target, LazyFill()).
+		Load(&lazyUser{}).
+		Run(func(u *lazyUser) {
+			if v, err := u.target.Get(); err != nil || v != target {
+				t.Errorf("lazy handle should resolve the goner loaded with LazyFill, got %v, %v", v, err)
+			}
+		})
+}
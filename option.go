@@ -2,6 +2,7 @@ package gone
 
 import (
 	"reflect"
+	"time"
 )
 
 // Option is an interface for configuring Goners loaded into the gone framework.
@@ -45,7 +46,11 @@ func IsDefault(objPointers ...any) Option {
 	return option{
 		apply: func(c *coffin) error {
 			if len(typeMap) == 0 {
-				typeMap[reflect.TypeOf(c.goner)] = true
+				if c.constructor != nil {
+					typeMap[c.constructor.t] = true
+				} else {
+					typeMap[reflect.TypeOf(c.goner)] = true
+				}
 			}
 			for t := range typeMap {
 				if err := c.AddToDefault(t); err != nil {
@@ -89,6 +94,23 @@ func LowStartPriority() Option {
 	return Order(100)
 }
 
+// IsFallback returns an Option that marks a Goner as a fallback implementation, which is the opposite of IsDefault.
+// A fallback Goner is only selected when no other compatible Goner exists, and it is replaced by any other Goner
+// loaded with the same name, without the ForceReplace option, whether it is loaded before or after the fallback.
+// It is intended for implementations shipped by frameworks and libraries, like the default EnvConfigure and Logger of gone.
+//
+// Example usage:
+//
+//	gone.Load(&MemoryCache{}, gone.IsFallback())
+func IsFallback() Option {
+	return option{
+		apply: func(c *coffin) error {
+			c.fallback = true
+			return nil
+		},
+	}
+}
+
 // Name returns an Option that sets a custom name for a Goner.
 // Components can be looked up by this name when injecting dependencies.
 //
@@ -157,3 +179,99 @@ func LazyFill() Option {
 		},
 	}
 }
+
+// Prototype returns an Option that marks a Goner as prototype scoped.
+// The loaded Goner is used as a template: every injection point (struct field, function parameter
+// or GetGonerByType call) receives a freshly allocated copy of it, which is filled and initialized
+// before being injected. Prototype cannot be used with providers, which are already called for every injection.
+// The copies are not tracked, so they are never destroyed by gone, see Destroyer; the Goners holding them should
+// release their resources if needed.
+//
+// Example usage:
+//
+//	gone.Load(&RequestHandler{}, gone.Prototype())
+func Prototype() Option {
+	return option{
+		apply: func(c *coffin) error {
+			if c.provider != nil || c.namedProvider != nil || c.constructor != nil {
+				return NewInnerErrorWithParams(LoadedError, "gone: Prototype() cannot be used with provider %q", c.Name())
+			}
+			c.prototype = true
+			return nil
+		},
+	}
+}
+
+// RequestScoped returns an Option that marks a Goner as request scoped.
+// The loaded Goner is used as a template: inside a request scope (see Application.NewRequestScope),
+// a copy of it is created, filled and initialized when it is first needed, and then shared by every
+// injection point of the same request scope. Request scoped Goners cannot be injected outside a request scope,
+// and cannot be used with providers. The copies are destroyed in the reverse order of creation when the request scope
+// is closed, see Scope.Close.
+//
+// Example usage:
+//
+//	gone.Load(&RequestContext{}, gone.RequestScoped())
+func RequestScoped() Option {
+	return option{
+		apply: func(c *coffin) error {
+			if c.provider != nil || c.namedProvider != nil || c.constructor != nil {
+				return NewInnerErrorWithParams(LoadedError, "gone: RequestScoped() cannot be used with provider %q", c.Name())
+			}
+			c.requestScoped = true
+			return nil
+		},
+	}
+}
+
+// InitTimeout returns an Option that limits the time of each call of the Init method of a Goner.
+// When Init does not return within d, the installation fails with the attempt history, and the call is abandoned
+// and keeps running in the background; it is not retried by InitRetry, so that Init never runs concurrently.
+// It can also be set by the config key "gone.init.<name>.timeout" of a named Goner, see InitPolicyFromConfig.
+//
+// Example usage:
+//
+//	gone.Load(&DbClient{}, gone.InitTimeout(5*time.Second))
+func InitTimeout(d time.Duration) Option {
+	return option{
+		apply: func(c *coffin) error {
+			if d <= 0 {
+				return NewInnerErrorWithParams(LoadedError, "gone: InitTimeout() of %q must be positive, got %s", c.Name(), d)
+			}
+			c.initPolicy.timeout = d
+			return nil
+		},
+	}
+}
+
+// InitRetry returns an Option that retries the Init method of a Goner at most n times when it returns an error
+// or panics. The wait before the first retry is backoff, and it doubles before every next retry.
+// When all attempts fail, the installation fails with the attempt history.
+// It can also be set by the config keys "gone.init.<name>.retry" and "gone.init.<name>.backoff" of a named Goner,
+// see InitPolicyFromConfig.
+//
+// Example usage:
+//
+//	gone.Load(&MqClient{}, gone.InitRetry(3, 100*time.Millisecond))
+func InitRetry(n int, backoff time.Duration) Option {
+	return option{
+		apply: func(c *coffin) error {
+			if n < 0 || backoff < 0 {
+				return NewInnerErrorWithParams(LoadedError, "gone: InitRetry() of %q must not be negative, got %d and %s", c.Name(), n, backoff)
+			}
+			c.initPolicy.retries = n
+			c.initPolicy.backoff = backoff
+			return nil
+		},
+	}
+}
+
+// builtin marks Goners loaded by gone itself, which are never reported as unused in strict mode.
+func builtin() Option {
+	return option{
+		apply: func(c *coffin) error {
+			c.builtin = true
+			return nil
+		},
+	}
+}
@@ -119,6 +119,19 @@ func TestForceReplace(t *testing.T) {
 	}
 }
 
+func TestIsFallback(t *testing.T) {
+	c := &coffin{}
+	opt := IsFallback()
+
+	if err := opt.Apply(c); err != nil {
+		t.Errorf("IsFallback().Apply() error = %v", err)
+	}
+
+	if !c.fallback {
+		t.Error("IsFallback() did not set fallback to true")
+	}
+}
+
 func TestPriorityOptions(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -248,3 +261,41 @@ func TestIsDefault(t *testing.T) {
 		}
 	})
 }
+
+func TestPrototype(t *testing.T) {
+	type x struct {
+		Flag
+	}
+
+	t.Run("goner", func(t *testing.T) {
+		c := newCoffin(&x{})
+		if err := Prototype().Apply(c); err != nil {
+			t.Errorf("Prototype().Apply() error = %v", err)
+		}
+		if !c.prototype {
+			t.Errorf("Prototype().Apply() should mark coffin as prototype")
+		}
+	})
+
+	t.Run("provider", func(t *testing.T) {
+		c := newCoffin(&g1Provider{})
+		if err := Prototype().Apply(c); err == nil {
+			t.Errorf("Prototype().Apply() should be error for provider")
+		}
+	})
+}
+
+func TestRequestScoped(t *testing.T) {
+	type x struct {
+		Flag
+	}
+
+	c := newCoffin(&x{})
+	if err := RequestScoped().Apply(c); err != nil || !c.requestScoped {
+		t.Errorf("RequestScoped().Apply() should mark coffin as request scoped, err = %v", err)
+	}
+
+	if err := RequestScoped().Apply(newCoffin(&g1Provider{})); err == nil {
+		t.Errorf("RequestScoped().Apply() should be error for provider")
+	}
+}
@@ -0,0 +1,88 @@
+package gone
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Optional is an explicitly optional dependency of type T. A struct field or a function parameter typed Optional[T]
+// is injected with the value of a compatible Goner when one exists, and is left empty otherwise, instead of
+// failing the injection. For struct fields, the gone tag works the same way as for a field typed T.
+//
+// Example usage:
+//
+//	type Service struct {
+//	    gone.Flag
+//	    cache gone.Optional[Cache] `gone:"*"`
+//	}
+//
+//	func (s *Service) Find(id string) {
+//	    if cache, ok := s.cache.Get(); ok {
+//	        ...
+//	    }
+//	}
+type Optional[T any] struct {
+	value   T
+	present bool
+}
+
+// Get returns the injected value, and whether a compatible Goner was found.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.present
+}
+
+// OrElse returns the injected value, or v if no compatible Goner was found.
+func (o Optional[T]) OrElse(v T) T {
+	if o.present {
+		return o.value
+	}
+	return v
+}
+
+func (o *Optional[T]) setOptional(v any) {
+	if v != nil {
+		o.value = v.(T)
+	}
+	o.present = true
+}
+
+type optionalSetter interface {
+	setOptional(v any)
+}
+
+var optionalSetterType = reflect.TypeOf((*optionalSetter)(nil)).Elem()
+
+// optionalTargetField returns a field of type T standing for the value injected into an Optional[T] field,
+// which allows nil. The target of a field without a gone tag has no gone tag either, so it is not injected.
+func optionalTargetField(field reflect.StructField) (reflect.StructField, bool) {
+	if field.Type.Kind() != reflect.Struct || !reflect.PointerTo(field.Type).Implements(optionalSetterType) {
+		return field, false
+	}
+	target := field
+	target.Type = field.Type.Field(0).Type
+	if tag, ok := field.Tag.Lookup(goneTag); ok {
+		target.Tag = reflect.StructTag(fmt.Sprintf(`%s:%q %s:%q`, goneTag, tag, optionTag, allowNil))
+	}
+	return target, true
+}
+
+// injectOptional injects the value of target into the Optional field v if a compatible Goner is found.
+func injectOptional(s iInstaller, target reflect.StructField, v reflect.Value, coName string) (from []*coffin, err error) {
+	if !target.IsExported() {
+		v = BlackMagic(v)
+	}
+
+	rv := reflect.New(target.Type).Elem()
+	if err = s.analyzerFieldDependencies(target, coName,
+		func(asSlice, byName bool, extend string, coffins ...*coffin) error {
+			from = coffins
+			return s.injectField(asSlice, byName, extend, coffins, target, rv, coName)
+		},
+	); err != nil {
+		return nil, err
+	}
+	if from != nil {
+		v.Addr().Interface().(optionalSetter).setOptional(rv.Interface())
+	}
+	return from, nil
+}
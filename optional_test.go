@@ -0,0 +1,105 @@
+package gone
+
+import (
+	"reflect"
+	"testing"
+)
+
+type optionalDep struct {
+	Flag
+}
+
+type optionalMissing interface {
+	missing()
+}
+
+func TestOptional(t *testing.T) {
+	type optionalUser struct {
+		Flag
+		dep     Optional[*optionalDep]    `gone:"*"`
+		named   Optional[*optionalDep]    `gone:"dep"`
+		missing Optional[optionalMissing] `gone:"*"`
+		list    Optional[[]*optionalDep]  `gone:"*"`
+		noTag   Optional[*optionalDep]
+	}
+
+	NewApp().
+		Load(&optionalUser{}).
+		Load(&optionalDep{}, Name("dep")).
+		Run(func(u *optionalUser, dep *optionalDep, p1 Optional[*optionalDep], p2 Optional[optionalMissing]) {
+			if v, ok := u.dep.Get(); !ok || v != dep {
+				t.Error("optional field should be injected when a compatible goner exists")
+			}
+			if v, ok := u.named.Get(); !ok || v != dep {
+				t.Error("optional field should be injected by name")
+			}
+			if v, ok := u.missing.Get(); ok || v != nil {
+				t.Error("optional field should be empty when no compatible goner exists")
+			}
+			if v, ok := u.list.Get(); !ok || len(v) != 1 {
+				t.Error("optional slice field should be injected")
+			}
+			if _, ok := u.noTag.Get(); ok {
+				t.Error("optional field without gone tag should not be injected")
+			}
+			if v, ok := p1.Get(); !ok || v != dep {
+				t.Error("optional parameter should be injected when a compatible goner exists")
+			}
+			if _, ok := p2.Get(); ok {
+				t.Error("optional parameter should be empty when no compatible goner exists")
+			}
+			if p2.OrElse(nil) != nil || p1.OrElse(nil) != dep {
+				t.Error("unexpected OrElse result")
+			}
+		})
+}
+
+func Test_optionalTargetField(t *testing.T) {
+	var x struct {
+		a Optional[*optionalDep] `gone:"dep"`
+		b struct{ value int }
+		c Optional[*optionalDep]
+	}
+	of := reflect.TypeOf(x)
+
+	target, ok := optionalTargetField(of.Field(0))
+	if !ok || target.Type != reflect.TypeOf(&optionalDep{}) || target.Tag.Get(goneTag) != "dep" || !isAllowNilField(&target) {
+		t.Errorf("unexpected target field %v", target)
+	}
+	if _, ok = optionalTargetField(of.Field(1)); ok {
+		t.Error("struct which is not Optional should not be treated as optional")
+	}
+	if target, ok = optionalTargetField(of.Field(2)); !ok {
+		t.Error("Optional field without gone tag should be treated as optional")
+	} else if _, tagged := target.Tag.Lookup(goneTag); tagged {
+		t.Errorf("target of a field without gone tag should not be tagged, got %q", target.Tag)
+	}
+}
+
+type optionalCycleA struct {
+	Flag
+	b Optional[*optionalCycleB]
+}
+
+func (a *optionalCycleA) Init() {}
+
+type optionalCycleB struct {
+	Flag
+	a *optionalCycleA `gone:"*"`
+}
+
+func (b *optionalCycleB) Init() {}
+
+func TestOptional_noTagIsNoDependency(t *testing.T) {
+	app := NewApp(StrictMode()).
+		Load(&optionalCycleA{}).
+		Load(&optionalCycleB{})
+	for _, e := range app.Graph().Edges {
+		if e.Field == "b" {
+			t.Errorf("optional field without gone tag should not be an edge, got %v", e)
+		}
+	}
+	if err := app.loader.Install(); err != nil {
+		t.Fatalf("optional field without gone tag should not be a dependency, got %v", err)
+	}
+}
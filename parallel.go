@@ -0,0 +1,219 @@
+package gone
+
+import (
+	"sort"
+)
+
+const parallelInitName = "gone-parallel-init"
+
+type parallelInit struct {
+	Flag
+	workers int
+}
+
+// ParallelInit returns a LoadFunc which enables installing Goners in parallel with at most workers goroutines.
+//
+// By default, Goners are filled and initialized one by one. With ParallelInit, the fill and init actions which do not
+// depend on each other, according to the dependency graph built when checking circular dependencies, run
+// concurrently, which speeds up applications with many slow Init methods, like those opening network clients.
+// An action still runs only after all the actions it depends on have completed, and LazyFill Goners which no other
+// Goner depends on are still filled at last.
+//
+// Errors are reported deterministically: when actions fail, no action after the first failed one in the serial
+// install order is started, and the error of the first failed one is returned, the same as installing serially.
+//
+// Init methods, BeforeInit methods and Providers of Goners which do not depend on each other must be safe to run
+// concurrently. Request scopes are always installed serially.
+//
+// Example usage:
+//
+//	gone.NewApp(gone.ParallelInit(runtime.NumCPU())).Load(&Cache{}).Load(&Database{}).Serve()
+func ParallelInit(workers int) LoadFunc {
+	return func(loader Loader) error {
+		if workers < 1 {
+			return NewInnerErrorWithParams(LoadedError, "workers of ParallelInit must be positive, got %d", workers)
+		}
+		return loader.Load(&parallelInit{workers: workers}, Name(parallelInitName), builtin())
+	}
+}
+
+// parallelWorkers returns the number of workers set by ParallelInit, or 0 if the core is installed serially.
+func (s *core) parallelWorkers() int {
+	if s.requestInstances != nil {
+		// instances of request scoped goners are cached in a map which is not safe for concurrent use
+		return 0
+	}
+	co := s.iKeeper.getByName(parallelInitName)
+	if co == nil {
+		return 0
+	}
+	if p, ok := co.goner.(*parallelInit); ok {
+		return p.workers
+	}
+	return 0
+}
+
+type installResult struct {
+	index int
+	err   error
+}
+
+// installParallel executes the actions of orders with workers goroutines. An action is started when all the actions
+// it depends on in the install graph have completed, and actions ready at the same time are started in the order
+// of orders. Actions of builtin goners and loggers, which are used by all the others, run alone, and before the
+// other actions ready at the same time.
+func (s *core) installParallel(orders []dependency, workers int) error {
+	index := make(map[dependency]int, len(orders))
+	for i, dep := range orders {
+		index[dep] = i
+	}
+
+	// pending[i] is the number of uncompleted actions orders[i] depends on, and dependents[j] are the actions
+	// depending on orders[j]
+	pending := make([]int, len(orders))
+	dependents := make([][]int, len(orders))
+	dependsOn := func(i, j int) {
+		pending[i]++
+		dependents[j] = append(dependents[j], i)
+	}
+	graph := s.iDependenceAnalyzer.getInstallGraph()
+	for i, dep := range orders {
+		for _, d := range graph[dep] {
+			if j, ok := index[d]; ok && j != i {
+				dependsOn(i, j)
+			}
+		}
+	}
+	var lazy []int
+	isLazy := make([]bool, len(orders))
+	for i, dep := range orders {
+		if dep.action == fillAction && dep.coffin.lazyFill && len(dependents[i]) == 0 {
+			lazy = append(lazy, i)
+			isLazy[i] = true
+		}
+	}
+	for _, i := range lazy {
+		for j := range orders {
+			if !isLazy[j] {
+				dependsOn(i, j)
+			}
+		}
+	}
+
+	// flags of coffins are read before starting, because workers set them concurrently
+	skipped := make([]bool, len(orders))
+	alone := make([]bool, len(orders))
+	remaining := make(map[*coffin]int)
+	executed := make(map[*coffin]bool)
+	for i, dep := range orders {
+		skipped[i] = isInstalled(dep)
+		_, isLogger := dep.coffin.goner.(Logger)
+		alone[i] = dep.coffin.builtin || isLogger
+		remaining[dep.coffin]++
+	}
+
+	jobs := make(chan int)
+	results := make(chan installResult, workers)
+	defer close(jobs)
+	for w := 0; w < workers; w++ {
+		go func() {
+			for i := range jobs {
+				results <- installResult{index: i, err: s.installOne(i, orders[i])}
+			}
+		}()
+	}
+
+	// ready are the actions which can be started, the ones run alone first, so that they are not postponed until
+	// no other action is running, and then in the order of orders
+	var ready []int
+	before := func(i, j int) bool {
+		if alone[i] != alone[j] {
+			return alone[i]
+		}
+		return i < j
+	}
+	push := func(i int) {
+		k := sort.Search(len(ready), func(k int) bool {
+			return before(i, ready[k])
+		})
+		ready = append(ready, 0)
+		copy(ready[k+1:], ready[k:])
+		ready[k] = i
+	}
+	completed := 0
+	complete := func(i int) {
+		completed++
+		for _, d := range dependents[i] {
+			if pending[d]--; pending[d] == 0 {
+				push(d)
+			}
+		}
+		co := orders[i].coffin
+		if !skipped[i] {
+			executed[co] = true
+		}
+		if remaining[co]--; remaining[co] == 0 && executed[co] {
+			s.installOrder = append(s.installOrder, co)
+		}
+	}
+	for i := range orders {
+		if pending[i] == 0 {
+			push(i)
+		}
+	}
+
+	failed := len(orders)
+	errs := make(map[int]error)
+	running := 0
+	exclusive := false
+	for {
+		for !exclusive && running < workers {
+			// actions after a failed one are not started, the ones before it are, as they may fail too
+			k := 0
+			for k < len(ready) && ready[k] >= failed {
+				k++
+			}
+			if k == len(ready) {
+				break
+			}
+			i := ready[k]
+			if skipped[i] {
+				// goners of parent container, and the configure used by conditions, are already installed
+				ready = append(ready[:k], ready[k+1:]...)
+				complete(i)
+				continue
+			}
+			if alone[i] {
+				if running > 0 {
+					break
+				}
+				exclusive = true
+			}
+			ready = append(ready[:k], ready[k+1:]...)
+			jobs <- i
+			running++
+		}
+		if running == 0 {
+			break
+		}
+		r := <-results
+		running--
+		exclusive = false
+		if r.err != nil {
+			errs[r.index] = r.err
+			if r.index < failed {
+				failed = r.index
+			}
+			continue
+		}
+		complete(r.index)
+	}
+
+	if failed < len(orders) {
+		return errs[failed]
+	}
+	if completed < len(orders) {
+		return NewInnerErrorWithParams(FailInstall, "cannot install goners in parallel: %d of %d actions are not executed", len(orders)-completed, len(orders))
+	}
+	return nil
+}
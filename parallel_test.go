@@ -0,0 +1,149 @@
+package gone
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type parallelCounter struct {
+	running atomic.Int32
+	max     atomic.Int32
+
+	// met is closed when meet inits have entered, the inits entered before wait for it, so that they are running
+	// at the same time; it is nil when inits do not wait
+	meet    int32
+	arrived atomic.Int32
+	met     chan struct{}
+}
+
+// newParallelBarrier returns a parallelCounter whose first meet inits wait for each other.
+func newParallelBarrier(meet int32) *parallelCounter {
+	return &parallelCounter{meet: meet, met: make(chan struct{})}
+}
+
+func (c *parallelCounter) enter() {
+	n := c.running.Add(1)
+	for {
+		m := c.max.Load()
+		if n <= m || c.max.CompareAndSwap(m, n) {
+			break
+		}
+	}
+	if c.met != nil {
+		if c.arrived.Add(1) == c.meet {
+			close(c.met)
+		}
+		select {
+		case <-c.met:
+		case <-time.After(2 * time.Second):
+		}
+	}
+	c.running.Add(-1)
+}
+
+type parallelClient struct {
+	Flag
+	counter *parallelCounter
+	inited  bool
+	err     error
+}
+
+func (c *parallelClient) Init() error {
+	c.counter.enter()
+	if _, ok := c.err.(slowError); ok {
+		time.Sleep(50 * time.Millisecond)
+	}
+	c.inited = true
+	return c.err
+}
+
+type slowError struct {
+	error
+}
+
+type parallelService struct {
+	Flag
+	client *parallelClient `gone:"client-0"`
+	seen   bool
+}
+
+func (s *parallelService) Init() {
+	s.seen = s.client.inited
+}
+
+func loadParallelClients(app *Application, counter *parallelCounter, n int) {
+	for i := 0; i < n; i++ {
+		app.Load(&parallelClient{counter: counter}, Name("client-"+string(rune('0'+i))))
+	}
+}
+
+func TestParallelInit(t *testing.T) {
+	t.Run("run independent inits concurrently", func(t *testing.T) {
+		counter := newParallelBarrier(2)
+		service := &parallelService{}
+		app := NewApp(ParallelInit(3)).Load(service)
+		loadParallelClients(app, counter, 6)
+		if err := app.loader.Install(); err != nil {
+			t.Fatal(err)
+		}
+		if m := counter.max.Load(); m < 2 || m > 3 {
+			t.Errorf("expected 2 or 3 inits running at the same time, got %d", m)
+		}
+		if !service.seen {
+			t.Error("dependencies should be initialized before the goner depending on them")
+		}
+		if len(app.loader.installOrder) == 0 {
+			t.Error("install order should be recorded")
+		}
+	})
+
+	t.Run("serial by default", func(t *testing.T) {
+		counter := &parallelCounter{}
+		app := NewApp()
+		loadParallelClients(app, counter, 3)
+		if err := app.loader.Install(); err != nil {
+			t.Fatal(err)
+		}
+		if m := counter.max.Load(); m != 1 {
+			t.Errorf("expected inits running one by one, got %d", m)
+		}
+	})
+
+	t.Run("deterministic error", func(t *testing.T) {
+		for n := 0; n < 5; n++ {
+			counter := &parallelCounter{}
+			app := NewApp(ParallelInit(4)).
+				Load(&parallelClient{counter: counter, err: errors.New("client-a failed")}, Name("client-a")).
+				Load(&parallelClient{counter: counter, err: errors.New("client-b failed")}, Name("client-b"))
+			orders, err := app.loader.Check()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// the failure first in the install order is reported, even if another one fails earlier
+			want := ""
+			for _, dep := range orders {
+				if c, ok := dep.coffin.goner.(*parallelClient); ok && dep.action == initAction {
+					if want == "" {
+						want = c.err.Error()
+						c.err = slowError{c.err}
+					}
+				}
+			}
+			err = app.loader.installParallel(orders, 4)
+			if err == nil || !strings.Contains(err.Error(), want) {
+				t.Fatalf("expected error containing %q, got %v", want, err)
+			}
+		}
+	})
+
+	t.Run("invalid workers", func(t *testing.T) {
+		err := ParallelInit(0)(NewApp().loader)
+		if err == nil || !strings.Contains(err.Error(), "must be positive") {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+}
@@ -0,0 +1,271 @@
+package gone
+
+import (
+	"context"
+	"reflect"
+)
+
+// Scope is a short-lived child container created from an Application or from another Scope.
+// Think of it as a "project team" formed inside the company: the team can hire its own members
+// (Load Goners) and work with everyone in the company (inject Goners of the parent), but when the
+// project is over the team is dismissed (Close) and the company stays exactly as it was.
+//
+// Typical usage is one Scope per request, per job or per tenant:
+//
+//	app.Run(func(keeper gone.GonerKeeper) {
+//	    scope := app.NewScope().Load(&RequestHandler{})
+//	    defer scope.Close()
+//
+//	    _ = scope.Run(func(h *RequestHandler) {
+//	        h.Handle()
+//	    })
+//	})
+//
+// Lookup rules:
+//   - Goners loaded into the scope are looked up first, and shadow Goners of the parent with the same name
+//   - When a Goner cannot be found in the scope, it is looked up in the parent
+//   - Goners of the parent never see Goners loaded into the scope
+//
+// A Scope should be installed after its parent has been installed, e.g. inside Application.Run.
+// Scope is not safe for concurrent use; create one Scope per goroutine instead.
+type Scope struct {
+	loader *core
+	parent *Scope
+	ctx    context.Context
+	closed bool
+}
+
+func newScope(parent *core) *Scope {
+	return &Scope{loader: parent.newChild()}
+}
+
+// NewScope creates a child Scope of the Application, and loads the given LoadFuncs into it.
+// LoadFuncs already loaded into the Application are not loaded again.
+func (s *Application) NewScope(loads ...LoadFunc) *Scope {
+	return newScope(s.loader).Loads(loads...)
+}
+
+// NewScope creates a nested child Scope, which can inject Goners of this Scope and of all its parents.
+func (s *Scope) NewScope(loads ...LoadFunc) *Scope {
+	child := newScope(s.loader)
+	child.parent = s
+	return child.Loads(loads...)
+}
+
+// NewRequestScope creates a request scope of the Application bound to ctx, and loads the given LoadFuncs into it.
+// Think of it as a "service desk" opened for one customer: Goners marked with RequestScoped() are created once
+// for the request scope and shared by everything injected from it, and the context.Context returned by
+// Scope.Context can be injected into functions and passed to FromContext.
+//
+// Example usage:
+//
+//	scope := app.NewRequestScope(r.Context())
+//	defer scope.Close()
+//
+//	fn, err := scope.InjectWrapFunc(func(user *CurrentUser, ctx context.Context) {
+//	    // user is created once for this request
+//	}, nil, nil)
+func (s *Application) NewRequestScope(ctx context.Context, loads ...LoadFunc) *Scope {
+	return newRequestScope(s.loader, ctx).Loads(loads...)
+}
+
+// NewRequestScope creates a request scope which is a child of this Scope, see Application.NewRequestScope.
+func (s *Scope) NewRequestScope(ctx context.Context, loads ...LoadFunc) *Scope {
+	child := newRequestScope(s.loader, ctx)
+	child.parent = s
+	return child.Loads(loads...)
+}
+
+func newRequestScope(parent *core, ctx context.Context) *Scope {
+	scope := &Scope{loader: parent.newRequestChild()}
+	scope.ctx = context.WithValue(ctx, requestScopeKey{}, scope)
+	scope.Load(&requestContextProvider{ctx: scope.ctx}, builtin())
+	return scope
+}
+
+// Context returns the context.Context bound to the request scope, which can be passed to FromContext.
+// For a Scope which is not a request scope, it returns the context of the nearest request scope among its parents,
+// or context.Background() if there is none.
+func (s *Scope) Context() context.Context {
+	for scope := s; scope != nil; scope = scope.parent {
+		if scope.ctx != nil {
+			return scope.ctx
+		}
+	}
+	return context.Background()
+}
+
+// Load loads a Goner into the Scope with optional configuration options. It panics if loading fails.
+func (s *Scope) Load(goner Goner, options ...Option) *Scope {
+	s.loader.MustLoad(goner, options...)
+	return s
+}
+
+// LoadConstructor registers a constructor function into the Scope, see ConstructorLoader.LoadConstructor. It panics if loading fails.
+func (s *Scope) LoadConstructor(fn any, options ...Option) *Scope {
+	if err := s.loader.LoadConstructor(fn, options...); err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Loads executes multiple LoadFuncs in sequence to load Goners into the Scope. It panics if loading fails.
+func (s *Scope) Loads(loads ...LoadFunc) *Scope {
+	for _, fn := range loads {
+		s.loader.MustLoadX(fn)
+	}
+	return s
+}
+
+// Install fills and initializes the Goners loaded into the Scope. Goners of the parent are not installed again,
+// except that a parent Scope is installed first if needed.
+// It is called automatically by Run and the injection methods, and does nothing after the first successful call.
+func (s *Scope) Install() error {
+	if s.closed {
+		return NewInnerError("scope is already closed", NotSupport)
+	}
+	if s.loader.installed {
+		return nil
+	}
+	if s.parent != nil {
+		if err := s.parent.Install(); err != nil {
+			return err
+		}
+	}
+	if s.loader.parent != nil && !s.loader.parent.installed {
+		return NewInnerError("cannot install scope before its parent is installed", FailInstall)
+	}
+	return s.loader.Install()
+}
+
+// Run installs the Scope and executes the functions with dependencies injected from the Scope.
+// It returns the first error that occurs when installing the Scope or injecting a function, or that is returned
+// by a function whose last result is an error; the functions after it are not executed.
+func (s *Scope) Run(funcList ...any) error {
+	for _, fn := range funcList {
+		f, err := s.InjectWrapFunc(fn, nil, nil)
+		if err != nil {
+			return err
+		}
+		if err = returnedError(fn, f()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close tears down the Scope. The Goners installed by the Scope are destroyed in the reverse order of installation,
+// see Destroyer; closing a request scope destroys the instances of request scoped Goners created in it first.
+// The parent is not affected, and the Scope cannot be used any more.
+func (s *Scope) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.loader.destroy()
+}
+
+// InjectFuncParameters injects dependencies from the Scope into function parameters, see FuncInjector.
+func (s *Scope) InjectFuncParameters(fn any, injectBefore FuncInjectHook, injectAfter FuncInjectHook) ([]reflect.Value, error) {
+	if err := s.Install(); err != nil {
+		return nil, err
+	}
+	return s.loader.InjectFuncParameters(fn, injectBefore, injectAfter)
+}
+
+// InjectWrapFunc wraps a function with dependency injection from the Scope, see FuncInjector.
+func (s *Scope) InjectWrapFunc(fn any, injectBefore FuncInjectHook, injectAfter FuncInjectHook) (func() []any, error) {
+	if err := s.Install(); err != nil {
+		return nil, err
+	}
+	return s.loader.InjectWrapFunc(fn, injectBefore, injectAfter)
+}
+
+// InjectStruct injects dependencies from the Scope into struct fields, see StructInjector.
+func (s *Scope) InjectStruct(goner any) error {
+	if err := s.Install(); err != nil {
+		return err
+	}
+	return s.loader.InjectStruct(goner)
+}
+
+// Explain tells why the field of a Goner loaded into the Scope is injected, the Goners of the parents are
+// considered as well, see Application.Explain.
+func (s *Scope) Explain(goner any, fieldName string) (*Explanation, error) {
+	return s.loader.Explain(goner, fieldName)
+}
+
+// GetGonerByName retrieves a Goner by name from the Scope or its parents, see GonerKeeper.
+// It panics if the Scope cannot be installed.
+func (s *Scope) GetGonerByName(name string) any {
+	s.mustInstall()
+	return s.loader.GetGonerByName(name)
+}
+
+// GetGonerByType retrieves a Goner by type from the Scope or its parents, see GonerKeeper.
+// It panics if the Scope cannot be installed.
+func (s *Scope) GetGonerByType(t reflect.Type) any {
+	s.mustInstall()
+	return s.loader.GetGonerByType(t)
+}
+
+// GetGonerByPattern retrieves Goners matching the type and name pattern from the Scope and its parents, see GonerKeeper.
+// It panics if the Scope cannot be installed.
+func (s *Scope) GetGonerByPattern(t reflect.Type, pattern string) []any {
+	s.mustInstall()
+	return s.loader.GetGonerByPattern(t, pattern)
+}
+
+func (s *Scope) getGonerByType(t reflect.Type) (any, error) {
+	if err := s.Install(); err != nil {
+		return nil, err
+	}
+	return s.loader.getGonerByType(t)
+}
+
+func (s *Scope) mustInstall() {
+	if err := s.Install(); err != nil {
+		panic(err)
+	}
+}
+
+var _ GonerKeeper = (*Scope)(nil)
+var _ StructInjector = (*Scope)(nil)
+var _ FuncInjector = (*Scope)(nil)
+
+type requestScopeKey struct{}
+
+type requestContextProvider struct {
+	Flag
+	ctx context.Context
+}
+
+func (p *requestContextProvider) Provide() (context.Context, error) {
+	return p.ctx, nil
+}
+
+// FromContext retrieves a Goner of type T from the request scope bound to ctx.
+// The ctx must be returned by Scope.Context of a request scope, or derived from it.
+// Request scoped Goners are created once per request scope, so every call with the same request scope
+// returns the same instance.
+//
+// Example usage:
+//
+//	user, err := gone.FromContext[*CurrentUser](ctx)
+func FromContext[T any](ctx context.Context) (T, error) {
+	var t T
+	scope, ok := ctx.Value(requestScopeKey{}).(*Scope)
+	if !ok {
+		return t, NewInnerError("no request scope is bound to the context", NotSupport)
+	}
+
+	typ := reflect.TypeOf(&t).Elem()
+	v, err := scope.getGonerByType(typ)
+	if err != nil {
+		return t, ToError(err)
+	}
+	if v == nil {
+		return t, NewInnerErrorWithParams(GonerTypeNotFound, "no compatible value found for %q in request scope", GetTypeName(typ))
+	}
+	return v.(T), nil
+}
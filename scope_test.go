@@ -0,0 +1,262 @@
+package gone
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type scopeParentSvc struct {
+	Flag
+	initCount int
+}
+
+func (s *scopeParentSvc) Init() {
+	s.initCount++
+}
+
+type scopeChildSvc struct {
+	Flag
+	parent *scopeParentSvc `gone:"*"`
+	name   string
+}
+
+type scopeNamed struct {
+	Flag
+	from string
+}
+
+func TestScope(t *testing.T) {
+	t.Run("inject from parent", func(t *testing.T) {
+		app := NewApp().
+			Load(&scopeParentSvc{}).
+			Load(&scopeNamed{from: "parent"}, Name("named"))
+
+		app.Run(func(p *scopeParentSvc, keeper GonerKeeper) {
+			scope := app.NewScope().
+				Load(&scopeChildSvc{name: "child"}).
+				Load(&scopeNamed{from: "child"}, Name("named"))
+			defer func() {
+				_ = scope.Close()
+			}()
+
+			err := scope.Run(func(c *scopeChildSvc, named *scopeNamed, list []*scopeNamed) {
+				if c.parent != p {
+					t.Errorf("child should be injected with goner of parent")
+				}
+				if named.from != "child" {
+					t.Errorf("goner of child should shadow goner of parent")
+				}
+				if len(list) != 1 || list[0].from != "child" {
+					t.Errorf("shadowed goner of parent should not be in slice")
+				}
+			})
+			if err != nil {
+				t.Fatalf("scope.Run() error = %v", err)
+			}
+			if p.initCount != 1 {
+				t.Errorf("goner of parent should not be initialized again, got %d", p.initCount)
+			}
+			if keeper.GetGonerByType(reflect.TypeOf(&scopeChildSvc{})) != nil {
+				t.Errorf("parent should not see goners of child")
+			}
+			if scope.GetGonerByName("named").(*scopeNamed).from != "child" {
+				t.Errorf("GetGonerByName should look up child first")
+			}
+		})
+	})
+
+	t.Run("nested scope", func(t *testing.T) {
+		app := NewApp().Load(&scopeParentSvc{})
+		app.Run(func() {
+			scope := app.NewScope().Load(&scopeChildSvc{name: "child"})
+			nested := scope.NewScope()
+			err := nested.Run(func(c *scopeChildSvc, p *scopeParentSvc) {
+				if c.name != "child" || c.parent != p {
+					t.Errorf("nested scope should see goners of all parents")
+				}
+			})
+			if err != nil {
+				t.Fatalf("nested.Run() error = %v", err)
+			}
+		})
+	})
+
+	t.Run("load func loaded by parent", func(t *testing.T) {
+		var count int
+		load := func(loader Loader) error {
+			count++
+			return loader.Load(&scopeParentSvc{})
+		}
+		app := NewApp(load)
+		app.Run(func() {
+			_ = app.NewScope(load)
+		})
+		if count != 1 {
+			t.Errorf("LoadFunc loaded by parent should not be loaded again, got %d", count)
+		}
+	})
+
+	t.Run("parent not installed", func(t *testing.T) {
+		scope := NewApp().NewScope()
+		if err := scope.Install(); err == nil {
+			t.Errorf("Install() should be error before parent is installed")
+		}
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		app := NewApp()
+		app.Run(func() {
+			scope := app.NewScope()
+			_ = scope.Close()
+			if err := scope.Run(func() {}); err == nil {
+				t.Errorf("Run() should be error after scope is closed")
+			}
+		})
+	})
+
+	t.Run("returned error", func(t *testing.T) {
+		app := NewApp()
+		app.Run(func() {
+			scope := app.NewScope()
+			defer func() {
+				_ = scope.Close()
+			}()
+			called := false
+			err := scope.Run(func() error {
+				return errors.New("run failed")
+			}, func() {
+				called = true
+			})
+			if err == nil || err.Error() != "run failed" || called {
+				t.Errorf("Run() should return the error of a function and stop, got %v", err)
+			}
+		})
+	})
+}
+
+type requestUser struct {
+	Flag
+	parent *scopeParentSvc `gone:"*"`
+	id     int
+}
+
+var requestUserCounter int
+
+func (u *requestUser) Init() {
+	requestUserCounter++
+	u.id = requestUserCounter
+}
+
+type requestHandler struct {
+	Flag
+	user *requestUser `gone:"*"`
+}
+
+type singletonUsesRequest struct {
+	Flag
+	user *requestUser `gone:"*"`
+}
+
+func TestRequestScope(t *testing.T) {
+	t.Run("one instance per request scope", func(t *testing.T) {
+		app := NewApp().
+			Load(&scopeParentSvc{}).
+			Load(&requestUser{}, RequestScoped())
+
+		app.Run(func() {
+			var ids []int
+			for i := 0; i < 2; i++ {
+				scope := app.NewRequestScope(context.Background()).Load(&requestHandler{})
+				err := scope.Run(func(h *requestHandler, u *requestUser, ctx context.Context) {
+					if h.user != u {
+						t.Errorf("request scoped goner should be shared in one request scope")
+					}
+					if u.parent == nil {
+						t.Errorf("request scoped goner should be filled")
+					}
+					fromCtx, err := FromContext[*requestUser](ctx)
+					if err != nil {
+						t.Fatalf("FromContext() error = %v", err)
+					}
+					if fromCtx != u {
+						t.Errorf("FromContext should return the instance of the request scope")
+					}
+					ids = append(ids, u.id)
+				})
+				if err != nil {
+					t.Fatalf("scope.Run() error = %v", err)
+				}
+
+				nested, err := FromContext[*requestUser](scope.NewScope().Context())
+				if err != nil || nested.id != ids[i] {
+					t.Errorf("nested scope should share the request scoped instance")
+				}
+				_ = scope.Close()
+			}
+			if len(ids) != 2 || ids[0] == ids[1] {
+				t.Errorf("each request scope should create its own instance, got %v", ids)
+			}
+		})
+	})
+
+	t.Run("outside request scope", func(t *testing.T) {
+		err := SafeExecute(func() error {
+			NewApp().
+				Load(&scopeParentSvc{}).
+				Load(&requestUser{}, RequestScoped()).
+				Load(&singletonUsesRequest{}).
+				Run()
+			return nil
+		})
+		if err == nil {
+			t.Errorf("request scoped goner should not be injected outside a request scope")
+		}
+	})
+
+	t.Run("context field and lookup by name", func(t *testing.T) {
+		type requestCtxUser struct {
+			Flag
+			ctx context.Context `gone:"*"`
+			n   int
+		}
+		app := NewApp().Load(&requestCtxUser{}, Name("ctx-user"), RequestScoped())
+		app.Run(func() {
+			scope := app.NewRequestScope(context.Background())
+			defer func() {
+				_ = scope.Close()
+			}()
+
+			fromCtx, err := FromContext[*requestCtxUser](scope.Context())
+			if err != nil {
+				t.Fatalf("FromContext() error = %v", err)
+			}
+			if fromCtx.ctx != scope.Context() {
+				t.Errorf("the context of the request scope should be injected")
+			}
+			fromCtx.n = 42
+
+			byName, ok := scope.GetGonerByName("ctx-user").(*requestCtxUser)
+			if !ok || byName != fromCtx || byName.n != 42 {
+				t.Errorf("GetGonerByName should return the instance of the request scope, got %v", byName)
+			}
+		})
+	})
+
+	t.Run("FromContext without request scope", func(t *testing.T) {
+		if _, err := FromContext[*requestUser](context.Background()); err == nil {
+			t.Errorf("FromContext() should be error without request scope")
+		}
+	})
+
+	t.Run("FromContext not found", func(t *testing.T) {
+		app := NewApp()
+		app.Run(func() {
+			scope := app.NewRequestScope(context.Background())
+			if _, err := FromContext[*requestUser](scope.Context()); err == nil {
+				t.Errorf("FromContext() should be error when goner is not found")
+			}
+		})
+	})
+}
@@ -0,0 +1,105 @@
+package gone
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+var (
+	defaultStopSignals   = []os.Signal{syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT}
+	defaultReloadSignals = []os.Signal{syscall.SIGHUP}
+)
+
+// StopSignals sets the signals which make WaitEnd return, so that the Application is stopped.
+// They are SIGINT, SIGTERM and SIGQUIT by default.
+// When a stop signal is received again while the Application is stopping, the process exits at once with ExitForced.
+//
+// Returns the Application instance for method chaining.
+func (s *Application) StopSignals(signals ...os.Signal) *Application {
+	s.stopSignals = signals
+	return s
+}
+
+// ReloadSignals sets the signals which make WaitEnd reload the Application by calling Reload, instead of stopping it.
+// It is SIGHUP by default, and no signal reloads the Application when it is called without signals.
+//
+// Returns the Application instance for method chaining.
+func (s *Application) ReloadSignals(signals ...os.Signal) *Application {
+	s.reloadSignals = signals
+	return s
+}
+
+// Reload notifies Goners that they should reload, e.g. when a SIGHUP is received. The Configure named "configure",
+// which is a DynamicConfigure usually, is reloaded first; then the watchers registered by ConfWatcher are notified of
+// the keys whose values are changed, and the other Goners implementing Reloadable are reloaded in the order of
+// installation.
+// Reloading continues when a Goner fails, and all the failures are returned as a MultiError.
+func (s *Application) Reload() error {
+	var errs []error
+	configure := s.loader.iKeeper.getByName(ConfigureName)
+	if configure != nil {
+		errs = append(errs, reloadGoner(configure))
+	}
+	for _, co := range s.loader.installOrder {
+		// the Application implements Reloadable itself
+		if co != configure && co.goner != s {
+			errs = append(errs, reloadGoner(co))
+		}
+	}
+	return NewMultiError(ReloadError, "failed to reload the application", errs...)
+}
+
+func reloadGoner(co *coffin) error {
+	r, ok := co.goner.(Reloadable)
+	if !ok {
+		return nil
+	}
+	if err := SafeExecute(r.Reload); err != nil {
+		return ToErrorWithMsg(err, "failed to reload "+co.Name())
+	}
+	return nil
+}
+
+func (s *Application) isReloadSignal(sig os.Signal) bool {
+	for _, r := range s.reloadSignals {
+		if r == sig {
+			return true
+		}
+	}
+	return false
+}
+
+// waitStopSignal blocks until a signal other than the reload signals is received, and reloads the Application
+// for every reload signal received before it.
+func (s *Application) waitStopSignal() {
+	signal.Notify(s.signal, append(append([]os.Signal{}, s.stopSignals...), s.reloadSignals...)...)
+	s.watchingSignals.Store(true)
+	for sig := range s.signal {
+		if !s.isReloadSignal(sig) {
+			s.loader.logger.Infof("received signal %s, stopping the application", sig)
+			return
+		}
+		s.loader.logger.Infof("received signal %s, reloading the application", sig)
+		if err := s.Reload(); err != nil {
+			s.loader.logger.Errorf("%s", err)
+		}
+	}
+}
+
+// forceExitOnSignal exits the process when a stop signal is received again before done is closed.
+func (s *Application) forceExitOnSignal(done <-chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case sig := <-s.signal:
+			if s.isReloadSignal(sig) {
+				continue
+			}
+			s.loader.logger.Warnf("received signal %s again while stopping, force exit", sig)
+			s.exit(ExitForced)
+			return
+		}
+	}
+}
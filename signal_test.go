@@ -0,0 +1,203 @@
+package gone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type reloadLog struct {
+	mu    sync.Mutex
+	names []string
+}
+
+func (l *reloadLog) add(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.names = append(l.names, name)
+}
+
+func (l *reloadLog) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return strings.Join(l.names, ",")
+}
+
+type reloadConfigure struct {
+	EnvConfigure
+	log *reloadLog
+}
+
+func (c *reloadConfigure) Reload() error {
+	c.log.add("configure")
+	return nil
+}
+
+type reloadGonerA struct {
+	Flag
+	log *reloadLog
+	err error
+}
+
+func (g *reloadGonerA) Init() {}
+
+func (g *reloadGonerA) Reload() error {
+	g.log.add("a")
+	return g.err
+}
+
+type reloadGonerB struct {
+	Flag
+	a   *reloadGonerA `gone:"*"`
+	log *reloadLog
+}
+
+func (g *reloadGonerB) Init() {}
+
+func (g *reloadGonerB) Reload() error {
+	g.log.add("b")
+	return nil
+}
+
+func TestApplication_Reload(t *testing.T) {
+	t.Run("configure first", func(t *testing.T) {
+		log := &reloadLog{}
+		NewApp().
+			Load(&reloadConfigure{log: log}, Name(ConfigureName), ForceReplace()).
+			Load(&reloadGonerB{log: log}).
+			Load(&reloadGonerA{log: log}).
+			Run(func(app *Application) {
+				if err := app.Reload(); err != nil {
+					t.Fatal(err)
+				}
+			})
+		if got := log.String(); got != "configure,a,b" {
+			t.Errorf("got %s", got)
+		}
+	})
+
+	t.Run("continue after errors", func(t *testing.T) {
+		log := &reloadLog{}
+		NewApp().
+			Load(&reloadGonerB{log: log}).
+			Load(&reloadGonerA{log: log, err: errors.New("reload failed")}).
+			Run(func(app *Application) {
+				err := app.Reload()
+				var m MultiError
+				if !errors.As(err, &m) || m.Code() != ReloadError || !strings.Contains(err.Error(), "reload failed") {
+					t.Errorf("unexpected error %v", err)
+				}
+			})
+		if got := log.String(); got != "a,b" {
+			t.Errorf("got %s", got)
+		}
+	})
+}
+
+type reloadDynamicConfigure struct {
+	Flag
+	value string
+}
+
+func (c *reloadDynamicConfigure) Get(key string, v any, defaultVal string) error {
+	if key != "app.mode" {
+		return SetValue(reflect.ValueOf(v), v, defaultVal)
+	}
+	*(v.(*string)) = c.value
+	return nil
+}
+
+func (c *reloadDynamicConfigure) Notify(string, ConfWatchFunc) {}
+
+func (c *reloadDynamicConfigure) Reload() error {
+	c.value = "v2"
+	return nil
+}
+
+func TestApplication_Reload_watchers(t *testing.T) {
+	var changes []string
+	NewApp().
+		Load(&reloadDynamicConfigure{value: "v1"}, Name(ConfigureName), ForceReplace()).
+		Run(func(app *Application, watch ConfWatcher) {
+			watch("app.mode", func(oldVal, newVal any) {
+				changes = append(changes, fmt.Sprintf("%v->%v", oldVal, newVal))
+			})
+			for i := 0; i < 2; i++ {
+				if err := app.Reload(); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	if got := strings.Join(changes, ","); got != "v1->v2" {
+		t.Errorf("got %s", got)
+	}
+}
+
+func TestApplication_WaitEnd_reload(t *testing.T) {
+	log := &reloadLog{}
+	app := NewApp().Load(&reloadGonerA{log: log})
+	app.AfterStart(func() {
+		app.signal <- syscall.SIGHUP
+		go func() {
+			for log.String() == "" {
+				time.Sleep(time.Millisecond)
+			}
+			app.End()
+		}()
+	})
+	app.Serve()
+
+	if got := log.String(); got != "a" {
+		t.Errorf("got %s", got)
+	}
+}
+
+type blockingStopDaemon struct {
+	Flag
+	stopping chan struct{}
+	release  chan struct{}
+}
+
+func (d *blockingStopDaemon) Start(context.Context) error {
+	return nil
+}
+
+func (d *blockingStopDaemon) Stop(context.Context) error {
+	close(d.stopping)
+	<-d.release
+	return nil
+}
+
+func TestApplication_forceExit(t *testing.T) {
+	d := &blockingStopDaemon{stopping: make(chan struct{}), release: make(chan struct{})}
+	app := NewApp().Load(d).StopSignals(syscall.SIGTERM)
+
+	exitCode := make(chan int, 1)
+	app.exit = func(code int) {
+		exitCode <- code
+		close(d.release)
+	}
+	app.AfterStart(func() {
+		app.End()
+		go func() {
+			<-d.stopping
+			app.signal <- syscall.SIGTERM
+		}()
+	})
+	app.Serve()
+
+	select {
+	case code := <-exitCode:
+		if code != ExitForced {
+			t.Errorf("got exit code %d", code)
+		}
+	default:
+		t.Error("the process should be forced to exit")
+	}
+}
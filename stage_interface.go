@@ -198,6 +198,56 @@ type AfterStopper interface {
 	AfterStop()
 }
 
+// Destroyer interface defines components that hold resources, which must be released when the application stops.
+// Components implementing this interface will have their Destroy() method called during Gone's shutdown phase,
+// after all daemons have been stopped and AfterStop hooks have completed, in the reverse order of installation,
+// so a component is destroyed before the components it depends on.
+//
+// Components and values supplied by providers which implement io.Closer, but not Destroyer,
+// are closed in the same way.
+//
+// Example usage:
+//
+//	type Pool struct {
+//	    gone.Flag
+//	    conns []net.Conn
+//	}
+//
+//	func (p *Pool) Destroy() error {
+//	    for _, c := range p.conns {
+//	        _ = c.Close()
+//	    }
+//	    return nil
+//	}
+type Destroyer interface {
+	Destroy() error
+}
+
+// Reloadable interface defines components that can reload their state, like configuration files or certificates,
+// without restarting the application. Components implementing this interface will have their Reload() method called
+// when the application receives a reload signal, SIGHUP by default, or Application.Reload is called.
+// The Configure named "configure" is reloaded before other components, so a DynamicConfigure implementing
+// Reloadable can notify the watchers of changed keys before other components reload.
+//
+// Example usage:
+//
+//	type TLSCert struct {
+//	    gone.Flag
+//	    cert atomic.Pointer[tls.Certificate]
+//	}
+//
+//	func (c *TLSCert) Reload() error {
+//	    cert, err := tls.LoadX509KeyPair("cert.pem", "key.pem")
+//	    if err != nil {
+//	        return err
+//	    }
+//	    c.cert.Store(&cert)
+//	    return nil
+//	}
+type Reloadable interface {
+	Reload() error
+}
+
 // Gone Lifecycle:
 //
 // 1. Load: Components are loaded into the Gone container using the Load() method.
@@ -224,13 +274,15 @@ type AfterStopper interface {
 //    - AfterStart hooks are executed
 //
 // 5. End: The application runs until termination is triggered
-//    - Waits for SIGINT, SIGTERM or SIGQUIT signal
+//    - Waits for SIGINT, SIGTERM or SIGQUIT signal, which can be changed by StopSignals
+//    - Reloadable components are reloaded when SIGHUP is received
 //    - Can be triggered manually via End() method
 //
 // 6. Stop: Components are gracefully shut down
 //    - BeforeStop hooks are executed
-//    - Daemons are stopped in reverse order
+//    - Daemons are stopped in reverse order, within the timeout configured by "gone.shutdown.timeout"
 //    - AfterStop hooks are executed
+//    - Destroyers (and io.Closers) are called in reverse install order
 //    - Application terminates
 //
 
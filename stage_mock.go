@@ -314,3 +314,42 @@ func (mr *MockAfterStoperMockRecorder) AfterStop() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AfterStop", reflect.TypeOf((*MockAfterStoper)(nil).AfterStop))
 }
+
+// MockDestroyer is a mock of Destroyer interface.
+type MockDestroyer struct {
+	Flag
+	ctrl     *gomock.Controller
+	recorder *MockDestroyerMockRecorder
+	isgomock struct{}
+}
+
+// MockDestroyerMockRecorder is the mock recorder for MockDestroyer.
+type MockDestroyerMockRecorder struct {
+	mock *MockDestroyer
+}
+
+// NewMockDestroyer creates a new mock instance.
+func NewMockDestroyer(ctrl *gomock.Controller) *MockDestroyer {
+	mock := &MockDestroyer{ctrl: ctrl}
+	mock.recorder = &MockDestroyerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDestroyer) EXPECT() *MockDestroyerMockRecorder {
+	return m.recorder
+}
+
+// Destroy mocks base method.
+func (m *MockDestroyer) Destroy() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Destroy")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Destroy indicates an expected call of Destroy.
+func (mr *MockDestroyerMockRecorder) Destroy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Destroy", reflect.TypeOf((*MockDestroyer)(nil).Destroy))
+}
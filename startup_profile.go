@@ -0,0 +1,228 @@
+package gone
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProfileStartupKey is the config key which enables logging the StartupProfile when the Application is started.
+const ProfileStartupKey = "gone.startup.profile"
+
+// StartupAction is the kind of work recorded in a StartupProfile.
+type StartupAction string
+
+const (
+	StartupFill    StartupAction = "fill"    // injecting the fields of a Goner
+	StartupInit    StartupAction = "init"    // calling BeforeInit and Init methods of a Goner
+	StartupProvide StartupAction = "provide" // a call of a Provider, which is part of the fill action requesting it
+	StartupDaemon  StartupAction = "daemon"  // starting a Daemon
+)
+
+// StartupEntry is the duration of an action on a Goner.
+type StartupEntry struct {
+	Goner    string
+	Action   StartupAction
+	Duration time.Duration
+}
+
+func (e StartupEntry) String() string {
+	return fmt.Sprintf("%-8s %-7s %s", e.Duration.Round(time.Microsecond), e.Action, e.Goner)
+}
+
+// StartupProfile reports where the time of starting an Application is spent.
+type StartupProfile struct {
+	// Total is the time from the beginning of installing Goners to the end of starting daemons.
+	Total time.Duration
+
+	// Entries are all the recorded actions, sorted by Duration in descending order.
+	Entries []StartupEntry
+
+	// CriticalPath is the chain of fill and init actions, each depending on the previous one, which takes the longest
+	// time in total, followed by the daemons, which are started one by one after installing.
+	// Shortening the actions on it is the only way to speed up the startup.
+	CriticalPath []StartupEntry
+}
+
+// CriticalPathDuration returns the total duration of the actions on the critical path.
+func (p *StartupProfile) CriticalPathDuration() time.Duration {
+	var d time.Duration
+	for _, e := range p.CriticalPath {
+		d += e.Duration
+	}
+	return d
+}
+
+func (p *StartupProfile) String() string {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "startup profile: total %s, %d actions\n", p.Total.Round(time.Microsecond), len(p.Entries))
+	for _, e := range p.Entries {
+		_, _ = fmt.Fprintf(&b, "\t%s\n", e)
+	}
+	_, _ = fmt.Fprintf(&b, "critical path: %s", p.CriticalPathDuration().Round(time.Microsecond))
+	for _, e := range p.CriticalPath {
+		_, _ = fmt.Fprintf(&b, "\n\t%s", e)
+	}
+	return b.String()
+}
+
+// startupProfiler records the durations of installing Goners and starting daemons. It is safe for concurrent use,
+// because Goners may be installed in parallel, see ParallelInit.
+type startupProfiler struct {
+	mutex     sync.Mutex
+	recording bool
+	begin     time.Time
+	end       time.Time
+	orders    []dependency
+	graph     map[dependency][]dependency
+	actions   map[dependency]time.Duration
+	entries   []StartupEntry
+	daemons   []StartupEntry
+}
+
+func newStartupProfiler() *startupProfiler {
+	return &startupProfiler{}
+}
+
+// reset clears the records, and starts recording the installation.
+func (p *startupProfiler) reset() {
+	if p == nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.recording = true
+	p.begin = time.Now()
+	p.end = p.begin
+	p.orders = nil
+	p.graph = nil
+	p.actions = make(map[dependency]time.Duration)
+	p.entries = nil
+	p.daemons = nil
+}
+
+// installed stops recording the installation, and keeps the install orders and graph to find the critical path.
+func (p *startupProfiler) installed(orders []dependency, graph map[dependency][]dependency) {
+	if p == nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.recording = false
+	p.orders = orders
+	p.graph = graph
+	p.end = time.Now()
+}
+
+func (p *startupProfiler) recordAction(dep dependency, d time.Duration) {
+	if p == nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if !p.recording {
+		return
+	}
+	p.actions[dep] = d
+	p.entries = append(p.entries, actionEntry(dep, d))
+}
+
+func actionEntry(dep dependency, d time.Duration) StartupEntry {
+	action := StartupFill
+	if dep.action == initAction {
+		action = StartupInit
+	}
+	return StartupEntry{Goner: dep.coffin.Name(), Action: action, Duration: d}
+}
+
+// recordProvide records a call of a Provider, which is ignored after the installation.
+func (p *startupProfiler) recordProvide(co *coffin, d time.Duration) {
+	if p == nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if !p.recording {
+		return
+	}
+	p.entries = append(p.entries, StartupEntry{Goner: co.Name(), Action: StartupProvide, Duration: d})
+}
+
+func (p *startupProfiler) recordDaemon(name string, d time.Duration) {
+	if p == nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	e := StartupEntry{Goner: name, Action: StartupDaemon, Duration: d}
+	p.entries = append(p.entries, e)
+	p.daemons = append(p.daemons, e)
+	p.end = time.Now()
+}
+
+func (p *startupProfiler) report() *StartupProfile {
+	if p == nil {
+		return &StartupProfile{}
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	profile := &StartupProfile{
+		Total:   p.end.Sub(p.begin),
+		Entries: make([]StartupEntry, len(p.entries)),
+	}
+	copy(profile.Entries, p.entries)
+	sort.SliceStable(profile.Entries, func(i, j int) bool {
+		return profile.Entries[i].Duration > profile.Entries[j].Duration
+	})
+	profile.CriticalPath = append(p.criticalPath(), p.daemons...)
+	return profile
+}
+
+// criticalPath finds the longest path through the install graph, weighted by the durations of actions. The install
+// orders are a topological order of the graph, so the longest path ending at each action is found in one pass.
+func (p *startupProfiler) criticalPath() []StartupEntry {
+	index := make(map[dependency]int, len(p.orders))
+	for i, dep := range p.orders {
+		index[dep] = i
+	}
+	longest := make([]time.Duration, len(p.orders))
+	prev := make([]int, len(p.orders))
+	last := -1
+	for i, dep := range p.orders {
+		prev[i] = -1
+		for _, d := range p.graph[dep] {
+			if j, ok := index[d]; ok && j < i && (prev[i] < 0 || longest[j] > longest[prev[i]]) {
+				prev[i] = j
+			}
+		}
+		longest[i] = p.actions[dep]
+		if prev[i] >= 0 {
+			longest[i] += longest[prev[i]]
+		}
+		if last < 0 || longest[i] > longest[last] {
+			last = i
+		}
+	}
+
+	var path []StartupEntry
+	for i := last; i >= 0; i = prev[i] {
+		dep := p.orders[i]
+		if d, ok := p.actions[dep]; ok {
+			path = append(path, actionEntry(dep, d))
+		}
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// StartupProfile returns the durations of installing Goners and starting daemons in the last start of the
+// Application, with the critical path through the dependency graph. Setting the config key "gone.startup.profile"
+// to true logs the profile when the Application is started.
+func (s *Application) StartupProfile() *StartupProfile {
+	return s.loader.profiler.report()
+}
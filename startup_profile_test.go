@@ -0,0 +1,135 @@
+package gone
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+type profiledDb struct {
+	Flag
+}
+
+func (d *profiledDb) Init() {
+	time.Sleep(30 * time.Millisecond)
+}
+
+type profiledRepo struct {
+	Flag
+	db *profiledDb `gone:"*"`
+}
+
+func (r *profiledRepo) Init() {
+	time.Sleep(20 * time.Millisecond)
+}
+
+type profiledCache struct {
+	Flag
+}
+
+func (c *profiledCache) Init() {
+	time.Sleep(5 * time.Millisecond)
+}
+
+type profiledServer struct {
+	Flag
+	repo *profiledRepo `gone:"*"`
+}
+
+func (s *profiledServer) Start() error {
+	time.Sleep(10 * time.Millisecond)
+	return nil
+}
+
+func (s *profiledServer) Stop() error {
+	return nil
+}
+
+func TestApplication_StartupProfile(t *testing.T) {
+	app := NewApp().
+		Load(&profiledDb{}, Name("db")).
+		Load(&profiledRepo{}, Name("repo")).
+		Load(&profiledCache{}, Name("cache")).
+		Load(&profiledServer{}, Name("server"))
+	if err := app.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer func() {
+		_ = app.Stop(context.Background())
+	}()
+
+	profile := app.StartupProfile()
+	if profile.Total < profile.CriticalPathDuration() {
+		t.Errorf("total %s should include the critical path %s", profile.Total, profile.CriticalPathDuration())
+	}
+	for i := 1; i < len(profile.Entries); i++ {
+		if profile.Entries[i].Duration > profile.Entries[i-1].Duration {
+			t.Fatalf("entries should be sorted by duration, got %v", profile.Entries)
+		}
+	}
+	recorded := make(map[string]bool)
+	for _, e := range profile.Entries {
+		recorded[string(e.Action)+" "+e.Goner] = true
+	}
+	for _, want := range []string{
+		"init Goner(name=db)",
+		"init Goner(name=repo)",
+		"init Goner(name=cache)",
+		"daemon *github.com/gone-io/gone/v2.profiledServer",
+	} {
+		if !recorded[want] {
+			t.Errorf("%q should be recorded, got %v", want, profile.Entries)
+		}
+	}
+
+	path := profile.CriticalPath
+	if len(path) == 0 || path[len(path)-1].Action != StartupDaemon {
+		t.Fatalf("critical path should end with the daemons, got %v", path)
+	}
+	index := make(map[string]int)
+	for i, e := range path {
+		if i > 0 && e.Action != StartupDaemon && path[i-1].Action == StartupDaemon {
+			t.Errorf("daemons should follow the installing actions on the critical path, got %v", path)
+		}
+		index[string(e.Action)+" "+e.Goner] = i
+	}
+	db, dbOk := index["init Goner(name=db)"]
+	repo, repoOk := index["init Goner(name=repo)"]
+	if dbOk && repoOk && db > repo {
+		t.Errorf("actions on the critical path should be in dependency order, got %v", path)
+	}
+	if s := profile.String(); !strings.Contains(s, "critical path") || !strings.Contains(s, "Goner(name=cache)") {
+		t.Errorf("unexpected report %s", s)
+	}
+	for _, e := range profile.Entries {
+		if e.Action == StartupProvide && (e.Goner == "Goner(name=db)" || e.Goner == "Goner(name=repo)") {
+			t.Errorf("injecting a goner itself should not be recorded as a provide, got %s", e)
+		}
+	}
+}
+
+func TestStartupProfile_provide(t *testing.T) {
+	app := NewApp().
+		Load(WrapFunctionProvider(func(tagConf string, param struct{}) (*profiledCache, error) {
+			time.Sleep(5 * time.Millisecond)
+			return &profiledCache{}, nil
+		})).
+		Load(&struct {
+			Flag
+			cache *profiledCache `gone:"*"`
+		}{})
+	if err := app.Start(context.Background()); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer func() {
+		_ = app.Stop(context.Background())
+	}()
+
+	for _, e := range app.StartupProfile().Entries {
+		if e.Action == StartupProvide {
+			return
+		}
+	}
+	t.Errorf("the call of provider should be recorded, got %v", app.StartupProfile().Entries)
+}
@@ -0,0 +1,197 @@
+package gone
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// StrictModeKey is the config key to enable strict mode, see StrictModeFromConfig.
+const StrictModeKey = "gone.strict"
+
+const strictModeName = "gone-strict-mode"
+const strictModeConfigName = "gone-strict-mode-config"
+
+type strictMode struct {
+	Flag
+}
+
+// StrictMode returns a LoadFunc which enables strict mode. Strict mode can also be enabled by config,
+// see StrictModeFromConfig.
+//
+// In strict mode, the following issues fail the installation with a single StrictModeError listing all of them,
+// instead of being ignored or just logged:
+//   - Ambiguous resolutions: multiple compatible Goners are found for a field or a parameter without a default one
+//   - Unused Goners: a Goner is not injected into any other Goner, has no lifecycle methods (Init, BeforeInit,
+//     Start/Stop), and is not a decorator
+//   - Nil fields: an allowNil field is left nil because no compatible Goner is found
+//
+// Goners only used by functions passed to Application.Run are reported as unused, so in strict mode the
+// entry points of an application are expected to be Daemons or Goners with lifecycle methods.
+//
+// Example usage:
+//
+//	gone.NewApp(gone.StrictMode()).Load(&Service{}).Serve()
+func StrictMode() LoadFunc {
+	return func(loader Loader) error {
+		return loader.Load(&strictMode{}, Name(strictModeName), builtin())
+	}
+}
+
+// StrictModeFromConfig returns a LoadFunc which enables strict mode when the config key "gone.strict"
+// (see StrictModeKey) is true.
+//
+// The key is read after Goners are installed, so that the configure is installed in dependency order like any other
+// Goner, and the issues are reported by failing the installation after that, instead of before installing any Goner
+// like StrictMode does.
+//
+// Example usage:
+//
+//	gone.NewApp(gone.StrictModeFromConfig()).Load(&Service{}).Serve()
+func StrictModeFromConfig() LoadFunc {
+	return func(loader Loader) error {
+		return loader.Load(&strictMode{}, Name(strictModeConfigName), builtin())
+	}
+}
+
+func (s *core) isStrict() bool {
+	return s.iKeeper.getByName(strictModeName) != nil
+}
+
+// isStrictByConfig reads the config key of strict mode, if StrictModeFromConfig is loaded and the configure is
+// installed.
+func (s *core) isStrictByConfig() (bool, error) {
+	if s.iKeeper.getByName(strictModeConfigName) == nil {
+		return false, nil
+	}
+	co := s.iKeeper.getByName(ConfigureName)
+	if co == nil || !co.isInit {
+		return false, nil
+	}
+	configure, ok := co.goner.(Configure)
+	if !ok {
+		return false, NewInnerErrorWithParams(GonerTypeNotMatch, "%q does not implement Configure", ConfigureName)
+	}
+	var strict bool
+	if err := configure.Get(StrictModeKey, &strict, "false"); err != nil {
+		return false, ToErrorWithMsg(err, fmt.Sprintf("failed to read %q", StrictModeKey))
+	}
+	return strict, nil
+}
+
+// checkStrict reports the issues forbidden in strict mode of all goners in the keeper as a single error.
+func (s *dependenceAnalyzer) checkStrict() error {
+	var issues []string
+	used := make(map[*coffin]bool)
+
+	var checkFields func(fields []reflect.StructField, coName string, isParam bool)
+	checkFields = func(fields []reflect.StructField, coName string, isParam bool) {
+		for _, field := range fields {
+			if target, ok := lazyTargetField(field); ok && !isParam {
+				field = target
+			}
+			optional := false
+			if target, ok := optionalTargetField(field); ok {
+				field, optional = target, true
+			}
+			ambiguous, found := false, false
+			_ = s.analyzeFieldDependencies(field, coName, func() {
+				ambiguous = true
+			}, func(asSlice, byName bool, extend string, coffins ...*coffin) error {
+				found = true
+				for _, co := range coffins {
+					used[co] = true
+				}
+				return nil
+			})
+
+			if ambiguous {
+				var names []string
+				pattern, _ := ParseGoneTag(field.Tag.Get(goneTag))
+				for _, co := range withoutFallback(s.iKeeper.getByTypeAndPattern(field.Type, pattern)) {
+					names = append(names, co.Name())
+				}
+				issues = append(issues, fmt.Sprintf("ambiguous %s of %s: found multiple %s without a default: %s",
+					fieldDesc(field, isParam), coName, GetTypeName(field.Type), strings.Join(names, ", ")),
+				)
+			}
+
+			if optional {
+				continue
+			} else if !found && isParam {
+				// a struct parameter is created and its fields are injected
+				if t := field.Type; t.Kind() == reflect.Struct || t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct {
+					if t.Kind() == reflect.Ptr {
+						t = t.Elem()
+					}
+					checkFields(structFields(t), coName, false)
+				}
+			} else if !found && field.Type.Kind() != reflect.Slice && field.Type.Kind() != reflect.Map {
+				if _, ok := field.Tag.Lookup(goneTag); ok {
+					issues = append(issues, fmt.Sprintf("nil %s of %s: no compatible value found", fieldDesc(field, isParam), coName))
+				}
+			}
+		}
+	}
+
+	coffins := s.iKeeper.getAllCoffins()
+	for _, co := range coffins {
+		if co.requestScoped {
+			// fields of request scoped goners are resolved in request scopes, see collectDeps
+			continue
+		}
+		if t := reflect.TypeOf(co.goner); t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct {
+			checkFields(structFields(t.Elem()), co.Name(), false)
+		}
+		if co.constructor != nil {
+			checkFields(paramFields(co.constructor.fn, 0), co.Name(), true)
+		}
+		if co.decorator != nil {
+			checkFields(paramFields(co.decorator.fn, 1), co.Name(), true)
+		}
+	}
+
+	for _, co := range coffins {
+		if !used[co] && !co.builtin && co.decorator == nil && (co.constructor != nil || !hasLifecycle(co.goner)) {
+			issues = append(issues, fmt.Sprintf("unused %s: it is not injected into any other Goner", co.Name()))
+		}
+	}
+
+	if len(issues) > 0 {
+		return NewInnerErrorWithParams(StrictModeError, "strict mode found %d issue(s):\n\t%s",
+			len(issues), strings.Join(issues, "\n\t"),
+		)
+	}
+	return nil
+}
+
+func structFields(t reflect.Type) (fields []reflect.StructField) {
+	for i := 0; i < t.NumField(); i++ {
+		fields = append(fields, t.Field(i))
+	}
+	return fields
+}
+
+func paramFields(fn any, from int) (fields []reflect.StructField) {
+	ft := reflect.TypeOf(fn)
+	for i := from; i < ft.NumIn(); i++ {
+		fields = append(fields, newParameterField(i+1, ft.In(i)))
+	}
+	return fields
+}
+
+func fieldDesc(field reflect.StructField, isParam bool) string {
+	if isParam {
+		return fmt.Sprintf("parameter #%s", strings.TrimSuffix(strings.TrimPrefix(field.Name, "The"), "thParameter"))
+	}
+	return fmt.Sprintf("field %q", field.Name)
+}
+
+func hasLifecycle(goner any) bool {
+	switch goner.(type) {
+	case Initiator, InitiatorNoError, BeforeInitiator, BeforeInitiatorNoError,
+		BeforeStarter, AfterStarter, BeforeStopper, AfterStopper, Daemon, ContextDaemon:
+		return true
+	}
+	return false
+}
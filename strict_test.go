@@ -0,0 +1,187 @@
+package gone
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type strictRepo interface {
+	Find() string
+}
+
+type strictRepoA struct {
+	Flag
+}
+
+func (r *strictRepoA) Find() string {
+	return "a"
+}
+
+type strictRepoB struct {
+	Flag
+}
+
+func (r *strictRepoB) Find() string {
+	return "b"
+}
+
+type strictService struct {
+	Flag
+	repo strictRepo `gone:"*"`
+}
+
+func (s *strictService) Init() {}
+
+type strictRouter struct {
+	Flag
+}
+
+func (r *strictRouter) AfterStart() {}
+
+type strictWorker struct {
+	Flag
+}
+
+func (w *strictWorker) Start(context.Context) error { return nil }
+func (w *strictWorker) Stop(context.Context) error  { return nil }
+
+func TestStrictMode(t *testing.T) {
+	t.Run("no issue", func(t *testing.T) {
+		NewApp(StrictMode()).
+			Load(&strictService{}).
+			Load(&strictRepoA{}).
+			Run(func(s *strictService) {
+				if s.repo.Find() != "a" {
+					t.Error("unexpected repo")
+				}
+			})
+	})
+
+	t.Run("aggregated issues", func(t *testing.T) {
+		type unused struct {
+			Flag
+		}
+		type missing interface {
+			missing()
+		}
+		type nilField struct {
+			Flag
+			missing missing   `gone:"*" option:"allowNil"`
+			repos   []missing `gone:"*"`
+		}
+
+		err := NewApp(StrictMode()).
+			Load(&strictService{}).
+			Load(&strictRepoA{}, Name("repo-a")).
+			Load(&strictRepoB{}, Name("repo-b")).
+			Load(&unused{}, Name("unused")).
+			Load(&nilField{}, Name("nil-field")).
+			LoadConstructor(func(repo strictRepo) *strictService {
+				return &strictService{}
+			}, Name("constructed")).
+			loader.Install()
+
+		if !IsError(err, StrictModeError) {
+			t.Fatalf("unexpected error %v", err)
+		}
+		for _, issue := range []string{
+			`ambiguous field "repo" of *gone.strictService`,
+			"without a default: Goner(name=repo-a), Goner(name=repo-b)",
+			`ambiguous parameter #1 of Goner(name=constructed)`,
+			"unused Goner(name=unused)",
+			"unused Goner(name=constructed)",
+			`nil field "missing" of Goner(name=nil-field)`,
+		} {
+			if !strings.Contains(err.Error(), issue) {
+				t.Errorf("error should contain %q, got %v", issue, err)
+			}
+		}
+		if strings.Contains(err.Error(), "unused Goner(name=repo-a)") {
+			t.Errorf("injected goner should not be reported as unused, got %v", err)
+		}
+	})
+
+	t.Run("hooks are used", func(t *testing.T) {
+		if err := NewApp(StrictMode()).Load(&strictRouter{}).loader.Install(); err != nil {
+			t.Errorf("goner with a hook should not be reported as unused, got %v", err)
+		}
+	})
+
+	t.Run("context daemons are used", func(t *testing.T) {
+		if err := NewApp(StrictMode()).Load(&strictWorker{}).loader.Install(); err != nil {
+			t.Errorf("ContextDaemon should not be reported as unused, got %v", err)
+		}
+	})
+
+	t.Run("enabled by config", func(t *testing.T) {
+		t.Setenv("GONE_GONE_STRICT", "true")
+		type unused struct {
+			Flag
+		}
+		err := NewApp(StrictModeFromConfig()).Load(&unused{}).loader.Install()
+		if !IsError(err, StrictModeError) {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+
+	t.Run("config ignored without StrictModeFromConfig", func(t *testing.T) {
+		t.Setenv("GONE_GONE_STRICT", "true")
+		type unused struct {
+			Flag
+		}
+		if err := NewApp().Load(&unused{}).loader.Install(); err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+
+	t.Run("configure depending on an Initiator", func(t *testing.T) {
+		source := &strictConfigSource{}
+		configure := &strictConfigure{}
+		app := NewApp(StrictModeFromConfig()).
+			Load(source).
+			Load(configure, Name(ConfigureName), ForceReplace())
+		if err := app.loader.Install(); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if !source.inited || !configure.ready {
+			t.Errorf("configure is not installed in dependency order")
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		type unused struct {
+			Flag
+		}
+		if err := NewApp().Load(&unused{}).loader.Install(); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+type strictConfigSource struct {
+	Flag
+	inited bool
+}
+
+func (s *strictConfigSource) Init() {
+	s.inited = true
+}
+
+type strictConfigure struct {
+	Flag
+	Source *strictConfigSource `gone:"*"`
+	ready  bool
+}
+
+func (c *strictConfigure) Init() {
+	c.ready = c.Source.inited
+}
+
+func (c *strictConfigure) Get(key string, v any, defaultVal string) error {
+	if !c.ready {
+		panic("configure is used before its source is initialized")
+	}
+	return SetValue(reflect.ValueOf(v), v, defaultVal)
+}
@@ -0,0 +1,60 @@
+package use_case
+
+import (
+	"github.com/gone-io/gone/v2"
+	"testing"
+)
+
+type store interface {
+	Store() string
+}
+
+type memoryStore struct {
+	gone.Flag
+}
+
+func (s *memoryStore) Store() string {
+	return "memory"
+}
+
+type redisStore struct {
+	gone.Flag
+}
+
+func (s *redisStore) Store() string {
+	return "redis"
+}
+
+type storeUser struct {
+	gone.Flag
+	store store `gone:"*"`
+}
+
+// loadStore loads redisStore when it is enabled by config, and memoryStore as the fallback
+func loadStore(loader gone.Loader) error {
+	if err := loader.Load(&redisStore{}, gone.OnConfig("store.redis", "on")); err != nil {
+		return err
+	}
+	return loader.Load(&memoryStore{}, gone.OnMissingType(new(store)))
+}
+
+func TestConditionalLoading(t *testing.T) {
+	gone.
+		NewApp(loadStore).
+		Load(&storeUser{}).
+		Run(func(u *storeUser) {
+			if u.store.Store() != "memory" {
+				t.Errorf("memory store should be used, got %s", u.store.Store())
+			}
+		})
+
+	t.Setenv("GONE_STORE_REDIS", "on")
+	gone.
+		NewApp(loadStore).
+		Load(&storeUser{}).
+		Run(func(u *storeUser) {
+			if u.store.Store() != "redis" {
+				t.Errorf("redis store should be used, got %s", u.store.Store())
+			}
+		})
+}
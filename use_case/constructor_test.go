@@ -0,0 +1,61 @@
+package use_case
+
+import (
+	"github.com/gone-io/gone/v2"
+	"testing"
+)
+
+// thirdPartyClient stands for a type from another library, which does not embed gone.Flag
+type thirdPartyClient struct {
+	addr string
+}
+
+func (c *thirdPartyClient) Addr() string {
+	return c.addr
+}
+
+type addrGetter interface {
+	Addr() string
+}
+
+type clientConfig struct {
+	gone.Flag
+	addr string
+}
+
+func (c *clientConfig) Init() {
+	c.addr = "127.0.0.1:6379"
+}
+
+type clientUser struct {
+	gone.Flag
+	client *thirdPartyClient `gone:"*"`
+	getter addrGetter        `gone:"*"`
+	named  *thirdPartyClient `gone:"client"`
+}
+
+func TestLoadConstructor(t *testing.T) {
+	gone.
+		NewApp().
+		Load(&clientUser{}).
+		LoadConstructor(func(conf *clientConfig, param struct {
+			logger gone.Logger `gone:"*"`
+		}) (*thirdPartyClient, error) {
+			if conf.addr == "" {
+				t.Error("dependencies of constructor should be initialized before it is called")
+			}
+			if param.logger == nil {
+				t.Error("struct parameter of constructor should be injected")
+			}
+			return &thirdPartyClient{addr: conf.addr}, nil
+		}, gone.Name("client")).
+		Load(&clientConfig{}).
+		Run(func(u *clientUser, c *thirdPartyClient) {
+			if u.client != c || u.named != c || u.getter != c {
+				t.Error("the constructed value should be injected by type, interface and name")
+			}
+			if c.Addr() != "127.0.0.1:6379" {
+				t.Errorf("unexpected addr %q", c.Addr())
+			}
+		})
+}
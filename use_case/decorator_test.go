@@ -0,0 +1,76 @@
+package use_case
+
+import (
+	"github.com/gone-io/gone/v2"
+	"testing"
+)
+
+type greeter interface {
+	Greet(name string) string
+}
+
+type plainGreeter struct {
+	gone.Flag
+}
+
+func (g *plainGreeter) Greet(name string) string {
+	return "hello " + name
+}
+
+type greetCounter struct {
+	gone.Flag
+	ready bool
+	count int
+}
+
+func (c *greetCounter) Init() {
+	c.ready = true
+}
+
+type countingGreeter struct {
+	greeter
+	counter *greetCounter
+}
+
+func (g *countingGreeter) Greet(name string) string {
+	g.counter.count++
+	return g.greeter.Greet(name)
+}
+
+type greeterUser struct {
+	gone.Flag
+	greeter  greeter   `gone:"*"`
+	greeters []greeter `gone:"*"`
+}
+
+func (u *greeterUser) Init() {
+	u.greeter.Greet("init")
+}
+
+func TestDecorate(t *testing.T) {
+	gone.
+		NewApp().
+		Load(&greeterUser{}).
+		Load(gone.Decorate[greeter](func(g greeter, counter *greetCounter) greeter {
+			if !counter.ready {
+				t.Error("dependencies of decorator should be initialized before it is called")
+			}
+			return &countingGreeter{greeter: g, counter: counter}
+		})).
+		Load(&plainGreeter{}).
+		Load(&greetCounter{}).
+		Run(func(u *greeterUser, g greeter, plain *plainGreeter, counter *greetCounter) {
+			if u.greeter != g || len(u.greeters) != 1 || u.greeters[0] != g {
+				t.Error("fields and parameters should receive the same decorated value")
+			}
+			if g.Greet("gone") != "hello gone" {
+				t.Error("decorated value should call the original implementation")
+			}
+			if counter.count != 2 {
+				t.Errorf("unexpected count %d", counter.count)
+			}
+			if plain.Greet("gone") != "hello gone" || counter.count != 2 {
+				t.Error("the original implementation should still be injectable by its own type")
+			}
+		})
+}
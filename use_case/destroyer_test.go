@@ -0,0 +1,47 @@
+package use_case
+
+import (
+	"github.com/gone-io/gone/v2"
+	"testing"
+)
+
+var destroyed []string
+
+type dbPool struct {
+	gone.Flag
+}
+
+func (p *dbPool) Init() {}
+
+func (p *dbPool) Destroy() error {
+	destroyed = append(destroyed, "pool")
+	return nil
+}
+
+type userRepository struct {
+	gone.Flag
+	pool *dbPool `gone:"*"`
+}
+
+func (r *userRepository) Init() {}
+
+func (r *userRepository) Close() error {
+	destroyed = append(destroyed, "repository")
+	return nil
+}
+
+func TestDestroyer(t *testing.T) {
+	destroyed = nil
+	gone.NewApp().
+		Load(&userRepository{}).
+		Load(&dbPool{}).
+		Run(func(r *userRepository) {
+			if len(destroyed) != 0 {
+				t.Error("goners should not be destroyed before the application stops")
+			}
+		})
+
+	if len(destroyed) != 2 || destroyed[0] != "repository" || destroyed[1] != "pool" {
+		t.Errorf("unexpected destroy order %v", destroyed)
+	}
+}
@@ -0,0 +1,40 @@
+package use_case
+
+import (
+	"github.com/gone-io/gone/v2"
+	"testing"
+)
+
+type mapConfigure struct {
+	gone.Flag
+	values map[string]string
+}
+
+func (c *mapConfigure) Get(key string, v any, defaultVal string) error {
+	value, ok := c.values[key]
+	if !ok {
+		value = defaultVal
+	}
+	*(v.(*string)) = value
+	return nil
+}
+
+type appName struct {
+	gone.Flag
+	name string `gone:"config,app.name"`
+}
+
+func TestIsFallback(t *testing.T) {
+	gone.
+		NewApp().
+		Load(&mapConfigure{values: map[string]string{"app.name": "demo"}}, gone.Name(gone.ConfigureName)).
+		Load(&appName{}).
+		Run(func(c gone.Configure, a *appName) {
+			if _, ok := c.(*mapConfigure); !ok {
+				t.Error("the configure should replace the fallback EnvConfigure")
+			}
+			if a.name != "demo" {
+				t.Errorf("unexpected app name %q", a.name)
+			}
+		})
+}
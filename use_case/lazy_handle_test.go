@@ -0,0 +1,46 @@
+package use_case
+
+import (
+	"github.com/gone-io/gone/v2"
+	"testing"
+)
+
+// orderService and paymentService are built by constructors depending on each other,
+// which is a real init cycle unless one of them is resolved lazily
+type orderService struct {
+	payment *paymentService
+}
+
+type paymentService struct {
+	orders gone.Lazy[*orderService]
+}
+
+type reportService struct {
+	gone.Flag
+	orders func() (*orderService, error) `gone:"*"`
+}
+
+func TestLazyHandle(t *testing.T) {
+	gone.
+		NewApp().
+		LoadConstructor(func(payment *paymentService) *orderService {
+			return &orderService{payment: payment}
+		}).
+		LoadConstructor(func(param struct {
+			orders gone.Lazy[*orderService] `gone:"*"`
+		}) *paymentService {
+			return &paymentService{orders: param.orders}
+		}).
+		Load(&reportService{}).
+		Run(func(o *orderService, p *paymentService, r *reportService) {
+			if o.payment != p {
+				t.Error("payment service should be injected into order service")
+			}
+			if p.orders.MustGet() != o {
+				t.Error("lazy handle should resolve the order service")
+			}
+			if orders, err := r.orders(); err != nil || orders != o {
+				t.Errorf("func handle should resolve the order service, err = %v", err)
+			}
+		})
+}
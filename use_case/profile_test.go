@@ -0,0 +1,53 @@
+package use_case
+
+import (
+	"github.com/gone-io/gone/v2"
+	"testing"
+)
+
+type mailer interface {
+	Send() string
+}
+
+type fakeMailer struct {
+	gone.Flag
+}
+
+func (m *fakeMailer) Send() string {
+	return "fake"
+}
+
+type smtpMailer struct {
+	gone.Flag
+	host string `gone:"config,mail.host"`
+}
+
+func (m *smtpMailer) Send() string {
+	return "smtp:" + m.host
+}
+
+func loadMailer(loader gone.Loader) error {
+	if err := loader.Load(&fakeMailer{}, gone.Profile("dev", "test")); err != nil {
+		return err
+	}
+	return loader.Load(&smtpMailer{}, gone.Profile("prod"))
+}
+
+func TestProfile(t *testing.T) {
+	t.Setenv("GONE_MAIL_HOST", "localhost")
+	t.Setenv("GONE_PROD_MAIL_HOST", "smtp.example.com")
+
+	t.Setenv("GONE_GONE_PROFILES_ACTIVE", "dev")
+	gone.NewApp(loadMailer).Run(func(m mailer) {
+		if m.Send() != "fake" {
+			t.Errorf("fake mailer should be used in dev, got %s", m.Send())
+		}
+	})
+
+	t.Setenv("GONE_GONE_PROFILES_ACTIVE", "prod")
+	gone.NewApp(loadMailer).Run(func(m mailer) {
+		if m.Send() != "smtp:smtp.example.com" {
+			t.Errorf("smtp mailer with prod config should be used in prod, got %s", m.Send())
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package use_case
+
+import (
+	"github.com/gone-io/gone/v2"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type protoDep struct {
+	gone.Flag
+	initialized bool
+}
+
+func (d *protoDep) Init() {
+	d.initialized = true
+}
+
+type protoSession struct {
+	gone.Flag
+	Tag       string
+	dep       *protoDep `gone:"*"`
+	initCount int
+}
+
+func (s *protoSession) Init() {
+	if !s.dep.initialized {
+		panic("protoDep should be initialized before prototype")
+	}
+	s.initCount++
+}
+
+type protoUser struct {
+	gone.Flag
+	s1 *protoSession `gone:"*"`
+	s2 *protoSession `gone:"*"`
+}
+
+func TestPrototype(t *testing.T) {
+	gone.
+		NewApp().
+		Load(&protoSession{Tag: "template"}, gone.Prototype()).
+		Load(&protoDep{}).
+		Load(&protoUser{}).
+		Run(func(u *protoUser, s *protoSession, keeper gone.GonerKeeper) {
+			if u.s1 == u.s2 || u.s1 == s {
+				t.Fatal("every injection should receive a new prototype instance")
+			}
+			for _, x := range []*protoSession{u.s1, u.s2, s} {
+				if x.Tag != "template" {
+					t.Errorf("prototype should be copied from template, got tag %q", x.Tag)
+				}
+				if x.initCount != 1 {
+					t.Errorf("prototype should be initialized once, got %d", x.initCount)
+				}
+				if x.dep == nil {
+					t.Error("prototype should be filled")
+				}
+			}
+
+			byType := keeper.GetGonerByType(reflect.TypeOf(&protoSession{}))
+			if byType == u.s1 || byType == nil {
+				t.Error("GetGonerByType should return a new prototype instance")
+			}
+		})
+}
+
+type protoSelf struct {
+	gone.Flag
+	self *protoSelf `gone:"*"`
+}
+
+func TestPrototype_SelfDependency(t *testing.T) {
+	err := gone.SafeExecute(func() error {
+		gone.
+			NewApp().
+			Load(&protoSelf{}, gone.Prototype()).
+			Run(func(*protoSelf) {})
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "circular dependency") {
+		t.Errorf("prototype depends on itself should be circular dependency error, got %v", err)
+	}
+}
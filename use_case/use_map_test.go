@@ -0,0 +1,35 @@
+package use_case
+
+import (
+	"github.com/gone-io/gone/v2"
+	"testing"
+)
+
+type dispatcher struct {
+	gone.Flag
+	workers       map[string]worker `gone:"*"`
+	patternFilter map[string]worker `gone:"worker*"`
+}
+
+func TestUseMap(t *testing.T) {
+	gone.
+		NewApp().
+		Load(&dispatcher{}).
+		Load(&workerImpl{name: "worker1"}, gone.Name("worker1")).
+		Load(&workerImpl2{name: "worker2"}, gone.Name("worker2")).
+		Load(&workerImpl{name: "unnamed"}).
+		Load(&workerImpl2{name: "other"}, gone.Name("other")).
+		Run(func(d *dispatcher, params struct {
+			workers map[string]worker `gone:"*"`
+		}) {
+			if len(d.workers) != 3 || d.workers["worker1"].(*workerImpl).name != "worker1" {
+				t.Errorf("all named workers should be injected by name, got %v", d.workers)
+			}
+			if len(d.patternFilter) != 2 || d.patternFilter["other"] != nil {
+				t.Errorf("workers should be filtered by pattern, got %v", d.patternFilter)
+			}
+			if len(params.workers) != 3 {
+				t.Errorf("map parameter fields should be injected, got %v", params.workers)
+			}
+		})
+}